@@ -0,0 +1,79 @@
+//go:build mage
+
+// Package main is the mage companion for Node/TypeScript projects, frontend
+// or backend. It shells out to pnpm rather than reimplementing JS tooling in
+// Go, so it stays thin: its value is giving a polyglot repo one `mage`
+// entry point instead of a separate invocation convention per stack (see
+// ../magefile-template for the Go-service counterpart and
+// ../magefile-template-rust for the Rust one).
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/magefile/mage/sh"
+)
+
+// containerImageEnv names the env var used to select the image repo:tag for
+// Image, e.g. IMAGE=ghcr.io/jpoley/rune-forge-service:v1.2.3. Matches the
+// convention in ../magefile-template/container.go.
+const containerImageEnv = "IMAGE"
+
+// InstallDeps runs `pnpm install --frozen-lockfile`.
+func InstallDeps() error {
+	return sh.RunV("pnpm", "install", "--frozen-lockfile")
+}
+
+// Build runs `tsc` for backend services, or the bundler's production build
+// for frontends, whichever the project's `build` script points at.
+func Build() error {
+	return sh.RunV("pnpm", "run", "build")
+}
+
+// Test runs the test suite (vitest or jest, whichever the project's `test`
+// script points at).
+func Test() error {
+	return sh.RunV("pnpm", "run", "test")
+}
+
+// Coverage runs the test suite with coverage collection enabled.
+func Coverage() error {
+	return sh.RunV("pnpm", "run", "test", "--", "--coverage")
+}
+
+// Lint runs eslint over the source tree.
+func Lint() error {
+	return sh.RunV("pnpm", "run", "lint")
+}
+
+// Dev starts the dev server with hot reload.
+func Dev() error {
+	return sh.RunV("pnpm", "run", "dev")
+}
+
+// Audit runs `npm audit` for known vulnerabilities in the dependency tree.
+func Audit() error {
+	return sh.RunV("npm", "audit")
+}
+
+// ScanVulnerabilities runs osv-scanner against the lockfile, catching
+// advisories npm audit's registry doesn't carry.
+func ScanVulnerabilities() error {
+	return sh.RunV("osv-scanner", "--lockfile", "pnpm-lock.yaml")
+}
+
+// SBOM generates a CycloneDX SBOM via cyclonedx-npm.
+func SBOM() error {
+	return sh.RunV("cyclonedx-npm", "--output-file", "sbom.cdx.json")
+}
+
+// Image builds a container image for the service. The image ref comes from
+// the IMAGE env var.
+func Image() error {
+	image := os.Getenv(containerImageEnv)
+	if image == "" {
+		return fmt.Errorf("%s must be set, e.g. IMAGE=ghcr.io/org/service:v1.2.3", containerImageEnv)
+	}
+	return sh.RunV("docker", "build", "-t", image, ".")
+}
@@ -0,0 +1,58 @@
+//go:build mage
+
+// Package main is the mage companion for Rust services. Like
+// ../magefile-template-node, it shells out to the stack's native tooling
+// (cargo) rather than reimplementing it in Go, so a polyglot repo gets one
+// `mage` entry point per stack instead of a different invocation convention
+// for each language.
+package main
+
+import "github.com/magefile/mage/sh"
+
+// Build runs a release build via `cargo build --release`.
+func Build() error {
+	return sh.RunV("cargo", "build", "--release")
+}
+
+// Test runs `cargo test`.
+func Test() error {
+	return sh.RunV("cargo", "test")
+}
+
+// Lint runs clippy, denying warnings so it behaves like a CI gate rather
+// than advice.
+func Lint() error {
+	return sh.RunV("cargo", "clippy", "--all-targets", "--", "-D", "warnings")
+}
+
+// Audit runs cargo-audit against the advisory database for known
+// vulnerabilities in the dependency graph.
+func Audit() error {
+	return sh.RunV("cargo", "audit")
+}
+
+// Deny runs cargo-deny's checks (advisories, license policy, banned crates,
+// duplicate versions), configured via deny.toml at the crate root.
+func Deny() error {
+	return sh.RunV("cargo", "deny", "check")
+}
+
+// SBOM generates a CycloneDX SBOM via cargo-cyclonedx.
+func SBOM() error {
+	return sh.RunV("cargo", "cyclonedx")
+}
+
+// CI runs the full gate in the same lint-then-test order as the Go
+// template's generated CI workflow, then the supply-chain checks.
+func CI() error {
+	if err := Lint(); err != nil {
+		return err
+	}
+	if err := Test(); err != nil {
+		return err
+	}
+	if err := Audit(); err != nil {
+		return err
+	}
+	return Deny()
+}
@@ -0,0 +1,60 @@
+//go:build mage
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/magefile/mage/sh"
+)
+
+// AffectedModules extends discoverModules by only returning modules whose
+// directory (or a shared root-level file) appears in the diff against
+// baseRef (default: origin/main), so CI can skip building/testing modules a
+// PR didn't touch.
+func AffectedModules(baseRef string) ([]string, error) {
+	if baseRef == "" {
+		baseRef = "origin/main"
+	}
+
+	diffOut, err := sh.Output("git", "diff", "--name-only", baseRef+"...HEAD")
+	if err != nil {
+		return nil, err
+	}
+	changed := strings.Split(diffOut, "\n")
+
+	modules, err := discoverModules()
+	if err != nil {
+		return nil, err
+	}
+
+	var affected []string
+	for _, dir := range modules {
+		for _, f := range changed {
+			if f == "" {
+				continue
+			}
+			if strings.HasPrefix(f, dir+"/") || !strings.Contains(f, "/") {
+				affected = append(affected, dir)
+				break
+			}
+		}
+	}
+	return affected, nil
+}
+
+// CIAffected runs the named target only in modules affected by the current
+// branch's diff against origin/main, printing a note and doing nothing if
+// none are affected.
+func CIAffected(target string) error {
+	modules, err := AffectedModules("")
+	if err != nil {
+		return err
+	}
+	if len(modules) == 0 {
+		fmt.Println("no modules affected by this change")
+		return nil
+	}
+	return runTargetInModules(target, modules)
+}
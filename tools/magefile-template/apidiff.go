@@ -0,0 +1,58 @@
+//go:build mage
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/magefile/mage/sh"
+)
+
+// pendingBumpEnv names the env var CI sets to the version-bump kind the
+// current change is headed for (major, minor, or patch), so APICheck knows
+// whether a breaking API change is actually allowed. Defaults to "minor",
+// the strictest default that still lets non-API changes through.
+const pendingBumpEnv = "PENDING_VERSION_BUMP"
+
+// APICheck runs gorelease against the latest released tag and fails unless
+// PENDING_VERSION_BUMP=major, so a breaking change to an exported API can't
+// ship under a minor or patch version. Essential for the shared library
+// repos using this template - an app repo with no external importers can
+// skip this target entirely.
+func APICheck() error {
+	baseTag, err := sh.Output("git", "describe", "--tags", "--abbrev=0")
+	if err != nil {
+		fmt.Println("no tags found, skipping APICheck")
+		return nil
+	}
+
+	report, err := sh.Output("gorelease", "-base="+baseTag)
+	// gorelease exits non-zero when it finds incompatible changes; that's
+	// the signal we're checking for, not necessarily a tool failure.
+	if err != nil && report == "" {
+		return fmt.Errorf("gorelease: %w", err)
+	}
+
+	if !hasIncompatibleChanges(report) {
+		return nil
+	}
+
+	bump := os.Getenv(pendingBumpEnv)
+	if bump == "" {
+		bump = "minor"
+	}
+	if bump == "major" {
+		fmt.Println("APICheck: incompatible changes found, but PENDING_VERSION_BUMP=major allows them:")
+		fmt.Println(report)
+		return nil
+	}
+
+	return fmt.Errorf("APICheck: incompatible API changes found but %s=%s (need major):\n%s",
+		pendingBumpEnv, bump, report)
+}
+
+func hasIncompatibleChanges(report string) bool {
+	return strings.Contains(report, "# Incompatible changes")
+}
@@ -0,0 +1,147 @@
+//go:build mage
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/magefile/mage/sh"
+)
+
+// archRule declares what a layer matching Package is allowed to import.
+// Once Package matches, an import of this module's own packages not covered
+// by Allow is denied, so listing the layers a package MAY depend on is
+// enough. Imports from outside the module are never flagged by Allow, and a
+// rule with an empty Allow allows everything. Deny additionally flags specific imports as violations
+// even if they'd otherwise be allowed by a broader Allow pattern, e.g. to
+// call out "nothing imports cmd" against an Allow of "./internal/...".
+type archRule struct {
+	// Package is the layer this rule governs, e.g. "./internal/handlers/...".
+	Package string   `yaml:"package" json:"package"`
+	Allow   []string `yaml:"allow" json:"allow"`
+	Deny    []string `yaml:"deny" json:"deny"`
+}
+
+// goListPackage is the subset of `go list -json` output ArchCheck needs.
+type goListPackage struct {
+	ImportPath string
+	Imports    []string
+}
+
+// ArchCheck enforces cfg.ArchRules: for every package matching a rule's
+// Package pattern, no import may match its Deny patterns, and every import
+// of this module's own packages must match one of its Allow patterns (when
+// Allow is non-empty). "./"-relative patterns are resolved against the
+// module path. A package matched by no rule is left alone, so
+// adopting ArchCheck in an existing module is incremental rather than
+// all-or-nothing.
+func ArchCheck() error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if len(cfg.ArchRules) == 0 {
+		return nil
+	}
+
+	pkgs, err := listPackages()
+	if err != nil {
+		return err
+	}
+	modPath := modulePath()
+
+	var violations []string
+	for _, pkg := range pkgs {
+		for _, rule := range cfg.ArchRules {
+			if !archPatternMatch(resolveArchPattern(rule.Package, modPath), pkg.ImportPath) {
+				continue
+			}
+			for _, imp := range pkg.Imports {
+				if v := checkImportAgainstRule(pkg.ImportPath, imp, rule, modPath); v != "" {
+					violations = append(violations, v)
+				}
+			}
+		}
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+	return fmt.Errorf("ArchCheck: %d import boundary violation(s):\n  %s",
+		len(violations), strings.Join(violations, "\n  "))
+}
+
+// checkImportAgainstRule reports a violation string if imp breaks rule for
+// pkg, or "" if it's fine. modPath is this module's import path (from
+// modulePath), passed in rather than recomputed per call so ArchCheck only
+// shells out to `go list -m` once for the whole run.
+func checkImportAgainstRule(pkg, imp string, rule archRule, modPath string) string {
+	for _, denied := range rule.Deny {
+		if archPatternMatch(resolveArchPattern(denied, modPath), imp) {
+			return fmt.Sprintf("%s imports %s, denied by rule %q", pkg, imp, rule.Package)
+		}
+	}
+	if len(rule.Allow) == 0 {
+		return ""
+	}
+	for _, allowed := range rule.Allow {
+		if archPatternMatch(resolveArchPattern(allowed, modPath), imp) {
+			return ""
+		}
+	}
+	// A package outside this module (e.g. "fmt", "github.com/x/y") isn't a
+	// layering concern; only flag imports of this module's own packages.
+	if !strings.HasPrefix(imp, modPath) {
+		return ""
+	}
+	return fmt.Sprintf("%s imports %s, not in allow list for rule %q", pkg, imp, rule.Package)
+}
+
+// archPatternMatch matches an import path against a Go package pattern:
+// a trailing "/..." matches the prefix and everything beneath it, anything
+// else must match exactly.
+func archPatternMatch(pattern, importPath string) bool {
+	prefix, isWildcard := strings.CutSuffix(pattern, "/...")
+	if !isWildcard {
+		return pattern == importPath
+	}
+	return importPath == prefix || strings.HasPrefix(importPath, prefix+"/")
+}
+
+// resolveArchPattern expands a "./"-relative pattern such as
+// "./internal/handlers/..." to a full import path under modPath, since
+// `go list` reports packages and their imports by full import path.
+func resolveArchPattern(pattern, modPath string) string {
+	if rest, ok := strings.CutPrefix(pattern, "./"); ok && modPath != "" {
+		return modPath + "/" + rest
+	}
+	return pattern
+}
+
+func listPackages() ([]goListPackage, error) {
+	out, err := sh.Output("go", "list", "-json", "./...")
+	if err != nil {
+		return nil, fmt.Errorf("go list: %w", err)
+	}
+
+	var pkgs []goListPackage
+	dec := json.NewDecoder(strings.NewReader(out))
+	for dec.More() {
+		var pkg goListPackage
+		if err := dec.Decode(&pkg); err != nil {
+			return nil, fmt.Errorf("decoding go list output: %w", err)
+		}
+		pkgs = append(pkgs, pkg)
+	}
+	return pkgs, nil
+}
+
+func modulePath() string {
+	out, err := sh.Output("go", "list", "-m")
+	if err != nil {
+		return ""
+	}
+	return out
+}
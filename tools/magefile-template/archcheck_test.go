@@ -0,0 +1,97 @@
+//go:build mage
+
+package main
+
+import "testing"
+
+func TestArchPatternMatch(t *testing.T) {
+	cases := []struct {
+		pattern    string
+		importPath string
+		want       bool
+	}{
+		{"./internal/handlers/...", "./internal/handlers", true},
+		{"./internal/handlers/...", "./internal/handlers/v1", true},
+		{"./internal/handlers/...", "./internal/handlersextra", false},
+		{"./internal/handlers/...", "./internal/services", false},
+		{"./cmd/service", "./cmd/service", true},
+		{"./cmd/service", "./cmd/service/internal", false},
+		{"./cmd/service", "./cmd", false},
+	}
+
+	for _, c := range cases {
+		got := archPatternMatch(c.pattern, c.importPath)
+		if got != c.want {
+			t.Errorf("archPatternMatch(%q, %q) = %v, want %v", c.pattern, c.importPath, got, c.want)
+		}
+	}
+}
+
+func TestCheckImportAgainstRule(t *testing.T) {
+	const modPath = "github.com/jpoley/rune-forge/tools/magefile-template"
+
+	cases := []struct {
+		name        string
+		pkg         string
+		imp         string
+		rule        archRule
+		wantViolate bool
+	}{
+		{
+			name: "allowed import passes",
+			pkg:  modPath + "/internal/handlers",
+			imp:  modPath + "/internal/services",
+			rule: archRule{
+				Package: "./internal/handlers/...",
+				Allow:   []string{modPath + "/internal/services/..."},
+			},
+		},
+		{
+			name: "import outside allow list on a module package is a violation",
+			pkg:  modPath + "/internal/handlers",
+			imp:  modPath + "/internal/repos",
+			rule: archRule{
+				Package: "./internal/handlers/...",
+				Allow:   []string{modPath + "/internal/services/..."},
+			},
+			wantViolate: true,
+		},
+		{
+			name: "stdlib/third-party imports are never a layering concern",
+			pkg:  modPath + "/internal/handlers",
+			imp:  "fmt",
+			rule: archRule{
+				Package: "./internal/handlers/...",
+				Allow:   []string{modPath + "/internal/services/..."},
+			},
+		},
+		{
+			name: "deny wins even over a matching allow",
+			pkg:  modPath + "/internal/handlers",
+			imp:  modPath + "/cmd",
+			rule: archRule{
+				Package: "./internal/handlers/...",
+				Allow:   []string{modPath + "/..."},
+				Deny:    []string{modPath + "/cmd"},
+			},
+			wantViolate: true,
+		},
+		{
+			name: "no allow list means anything goes",
+			pkg:  modPath + "/internal/handlers",
+			imp:  modPath + "/internal/anything",
+			rule: archRule{
+				Package: "./internal/handlers/...",
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := checkImportAgainstRule(c.pkg, c.imp, c.rule, modPath)
+			if (got != "") != c.wantViolate {
+				t.Errorf("checkImportAgainstRule(%q, %q) = %q, wantViolate %v", c.pkg, c.imp, got, c.wantViolate)
+			}
+		})
+	}
+}
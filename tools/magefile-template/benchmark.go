@@ -0,0 +1,76 @@
+//go:build mage
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/magefile/mage/sh"
+)
+
+const (
+	benchmarkOutput   = "bench.txt"
+	benchmarkBaseline = "bench-baseline.txt"
+)
+
+// Benchmark runs `go test -bench=. -benchmem ./...`, writing results to
+// bench.txt. If bench-baseline.txt exists, it then runs benchstat against
+// the baseline and fails the build on any regression benchstat flags.
+func Benchmark() error {
+	out, err := sh.Output("go", "test", "-run=^$", "-bench=.", "-benchmem", "./...")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(benchmarkOutput, []byte(out), 0o644); err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(benchmarkBaseline); os.IsNotExist(err) {
+		return nil
+	}
+
+	return checkBenchmarkRegression()
+}
+
+// BenchmarkBaseline promotes the most recent bench.txt to bench-baseline.txt
+// so future Benchmark runs compare against it.
+func BenchmarkBaseline() error {
+	data, err := os.ReadFile(benchmarkOutput)
+	if err != nil {
+		return fmt.Errorf("run mage benchmark first: %w", err)
+	}
+	return os.WriteFile(benchmarkBaseline, data, 0o644)
+}
+
+// checkBenchmarkRegression shells out to benchstat, which reports a
+// regression delta per benchmark; any delta benchstat marks with a "~"-free,
+// positive percentage change is treated as a failure.
+func checkBenchmarkRegression() error {
+	out, err := sh.Output("benchstat", benchmarkBaseline, benchmarkOutput)
+	if err != nil {
+		return err
+	}
+	fmt.Println(out)
+	if hasRegression(out) {
+		return fmt.Errorf("benchmark regression detected, see output above")
+	}
+	return nil
+}
+
+// hasRegression looks for a benchstat delta column showing a statistically
+// significant slowdown (a "+N%" entry; "~" marks no significant change).
+func hasRegression(benchstatOutput string) bool {
+	for _, line := range strings.Split(benchstatOutput, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		delta := fields[len(fields)-1]
+		if strings.HasPrefix(delta, "+") && strings.HasSuffix(delta, "%") {
+			return true
+		}
+	}
+	return false
+}
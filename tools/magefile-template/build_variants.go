@@ -0,0 +1,54 @@
+//go:build mage
+
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// BuildStatic builds a fully static binary (CGO_ENABLED=0), suitable for
+// FROM scratch / distroless container images.
+func BuildStatic() error {
+	return buildVariant("static", map[string]string{"CGO_ENABLED": "0"}, nil)
+}
+
+// BuildCGO builds with cgo enabled, needed when linking against libraries
+// like sqlite3 that don't have a pure-Go driver. It requires a C toolchain
+// on PATH.
+func BuildCGO() error {
+	return buildVariant("cgo", map[string]string{"CGO_ENABLED": "1"}, []string{"-linkmode", "external"})
+}
+
+func buildVariant(name string, env map[string]string, extraLdflags []string) error {
+	dir := filepath.Join(outputDir, name)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	args := []string{"build", "-o", filepath.Join(dir, binaryName), mainPath}
+	if len(extraLdflags) > 0 {
+		args = append([]string{args[0], "-ldflags", joinArgs(extraLdflags)}, args[1:]...)
+	}
+
+	cmd := exec.Command("go", args...)
+	cmd.Env = os.Environ()
+	for k, v := range env {
+		cmd.Env = append(cmd.Env, k+"="+v)
+	}
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+func joinArgs(args []string) string {
+	out := ""
+	for i, a := range args {
+		if i > 0 {
+			out += " "
+		}
+		out += a
+	}
+	return out
+}
@@ -0,0 +1,31 @@
+//go:build mage
+
+package main
+
+import "os"
+
+// remoteCacheEnv, when set to a GOCACHEPROG-compatible cache server command
+// (e.g. "gocacheprog -url https://cache.internal"), is wired into go build/
+// test/vet invocations via GOCACHEPROG so CI runners share build and test
+// cache instead of each starting cold.
+const remoteCacheEnv = "MAGE_REMOTE_CACHE"
+
+// withRemoteCache returns env, with GOCACHEPROG set from MAGE_REMOTE_CACHE
+// when present and the offline-mode overrides from offline.go layered on
+// top, for passing to sh.RunWith / exec.Cmd.Env.
+func withRemoteCache(env map[string]string) map[string]string {
+	cache := os.Getenv(remoteCacheEnv)
+	if cache != "" {
+		if env == nil {
+			env = map[string]string{}
+		}
+		env["GOCACHEPROG"] = cache
+	}
+	for k, v := range offlineGoEnv() {
+		if env == nil {
+			env = map[string]string{}
+		}
+		env[k] = v
+	}
+	return env
+}
@@ -0,0 +1,123 @@
+//go:build mage
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/magefile/mage/sh"
+)
+
+// cacheActionGraphPath is where CacheStats asks `go build -debug-actiongraph`
+// to write the action graph it then parses.
+const cacheActionGraphPath = "bin/build-actiongraph.json"
+
+// cacheAction is the subset of `go build -debug-actiongraph` JSON fields
+// CacheStats needs. The full schema (internal to cmd/go) carries many more;
+// anything not listed here is ignored by json.Unmarshal rather than erroring.
+type cacheAction struct {
+	Package   string
+	Mode      string
+	Cached    bool
+	TimeStart time.Time
+	TimeDone  time.Time
+}
+
+// CacheStats builds the module once with -debug-actiongraph, reports the
+// GOCACHE directory's size and this build's hit/miss rate, and ranks the
+// packages that took longest to rebuild — the ones most worth investigating
+// for a cache-busting embedded timestamp or similarly unstable input.
+func CacheStats() error {
+	size, err := goCacheSize()
+	if err != nil {
+		return fmt.Errorf("measuring GOCACHE: %w", err)
+	}
+	Step("CacheStats: GOCACHE size = %s", size)
+
+	actions, err := runWithActionGraph()
+	if err != nil {
+		return err
+	}
+
+	var hits, misses int
+	for _, a := range actions {
+		if a.Mode != "build" {
+			continue
+		}
+		if a.Cached {
+			hits++
+		} else {
+			misses++
+		}
+	}
+	total := hits + misses
+	if total == 0 {
+		Step("CacheStats: no build actions recorded (build was fully up to date already?)")
+		return nil
+	}
+	Step("CacheStats: %d/%d build actions were cache hits (%.0f%%)", hits, total, 100*float64(hits)/float64(total))
+
+	printSlowestMisses(actions)
+	return nil
+}
+
+func goCacheSize() (string, error) {
+	dir, err := sh.Output("go", "env", "GOCACHE")
+	if err != nil {
+		return "", err
+	}
+	out, err := sh.Output("du", "-sh", dir)
+	if err != nil {
+		return "", err
+	}
+	return out, nil
+}
+
+func runWithActionGraph() ([]cacheAction, error) {
+	if err := os.MkdirAll(filepath.Dir(cacheActionGraphPath), 0o755); err != nil {
+		return nil, err
+	}
+	// -a forces every package to actually be considered so a fully-cached
+	// tree still yields a complete action graph instead of an empty one.
+	if err := sh.RunV("go", "build", "-a", "-debug-actiongraph="+cacheActionGraphPath, "-o", os.DevNull, "./..."); err != nil {
+		return nil, fmt.Errorf("go build -debug-actiongraph: %w", err)
+	}
+
+	data, err := os.ReadFile(cacheActionGraphPath)
+	if err != nil {
+		return nil, err
+	}
+	var actions []cacheAction
+	if err := json.Unmarshal(data, &actions); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", cacheActionGraphPath, err)
+	}
+	return actions, nil
+}
+
+func printSlowestMisses(actions []cacheAction) {
+	misses := make([]cacheAction, 0, len(actions))
+	for _, a := range actions {
+		if a.Mode == "build" && !a.Cached && a.Package != "" {
+			misses = append(misses, a)
+		}
+	}
+	sort.Slice(misses, func(i, j int) bool {
+		return misses[i].TimeDone.Sub(misses[i].TimeStart) > misses[j].TimeDone.Sub(misses[j].TimeStart)
+	})
+
+	if len(misses) == 0 {
+		return
+	}
+	Step("CacheStats: slowest cache-invalidated packages:")
+	for i, a := range misses {
+		if i >= 10 {
+			break
+		}
+		fmt.Printf("  %-10s %s\n", a.TimeDone.Sub(a.TimeStart).Round(time.Millisecond), a.Package)
+	}
+}
@@ -0,0 +1,83 @@
+//go:build mage
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/magefile/mage/sh"
+)
+
+// toxiproxyAPIEnv points at a running toxiproxy server's control API, e.g.
+// http://localhost:8474, and is passed to toxiproxy-cli as its --host.
+// TestChaos fails when it is unset and chaosScenarios are configured, since
+// fault injection against nothing would just be the regular integration suite.
+const toxiproxyAPIEnv = "TOXIPROXY_API"
+
+// chaosScenario describes one fault to inject via toxiproxy for the
+// duration of the integration suite, e.g. 200ms of added latency on the
+// "postgres" proxy to verify a client's timeout actually fires.
+type chaosScenario struct {
+	Name      string  `yaml:"name" json:"name"`
+	Proxy     string  `yaml:"proxy" json:"proxy"`
+	ToxicType string  `yaml:"toxicType" json:"toxicType"` // latency, timeout, reset_peer, bandwidth
+	LatencyMS int     `yaml:"latencyMs" json:"latencyMs"`
+	Toxicity  float64 `yaml:"toxicity" json:"toxicity"` // fraction of connections affected, 0-1
+}
+
+// TestChaos runs IntegrationTest once per cfg.ChaosScenarios, injecting the
+// scenario's toxic into toxiproxy beforehand and always removing it
+// afterward (even on failure), to verify the service's retry/timeout
+// behavior under latency, resets, and partitions instead of just the happy
+// path IntegrationTest covers.
+func TestChaos() error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if len(cfg.ChaosScenarios) == 0 {
+		Step("TestChaos: no chaosScenarios configured, nothing to do")
+		return nil
+	}
+	if toxiproxyAPI() == "" {
+		return fmt.Errorf("%s must be set to a running toxiproxy server, e.g. http://localhost:8474", toxiproxyAPIEnv)
+	}
+
+	for _, scenario := range cfg.ChaosScenarios {
+		Step("TestChaos: running %q (%s on %s)", scenario.Name, scenario.ToxicType, scenario.Proxy)
+
+		if err := applyToxic(scenario); err != nil {
+			return fmt.Errorf("%s: injecting toxic: %w", scenario.Name, err)
+		}
+
+		testErr := IntegrationTest()
+
+		if err := removeToxic(scenario); err != nil && testErr == nil {
+			return fmt.Errorf("%s: removing toxic: %w", scenario.Name, err)
+		}
+		if testErr != nil {
+			return fmt.Errorf("%s: %w", scenario.Name, testErr)
+		}
+	}
+	return nil
+}
+
+func toxiproxyAPI() string {
+	return os.Getenv(toxiproxyAPIEnv)
+}
+
+const toxiproxyToxicName = "rune-forge-chaos"
+
+func applyToxic(s chaosScenario) error {
+	return sh.RunV("toxiproxy-cli", "--host", toxiproxyAPI(), "toxic", "add", s.Proxy,
+		"-n", toxiproxyToxicName,
+		"-t", s.ToxicType,
+		"-a", "latency="+strconv.Itoa(s.LatencyMS),
+		"-to", strconv.FormatFloat(s.Toxicity, 'f', -1, 64))
+}
+
+func removeToxic(s chaosScenario) error {
+	return sh.RunV("toxiproxy-cli", "--host", toxiproxyAPI(), "toxic", "remove", s.Proxy, "-n", toxiproxyToxicName)
+}
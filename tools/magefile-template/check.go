@@ -0,0 +1,13 @@
+//go:build mage
+
+package main
+
+import "os"
+
+// Check typechecks every package without linking or writing artifacts —
+// `go build -o /dev/null` still does full compilation, just skips the link
+// step Build pays for, so this is a fast pre-commit-hook-sized sanity gate
+// distinct from Build's ldflags/cross-compile/embed pipeline.
+func Check() error {
+	return runv("go", "build", "-o", os.DevNull, "./...")
+}
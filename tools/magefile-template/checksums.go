@@ -0,0 +1,103 @@
+//go:build mage
+
+package main
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+type digestAlgo string
+
+const (
+	sha256Algo digestAlgo = "sha256"
+	sha512Algo digestAlgo = "sha512"
+)
+
+// checksumManifest accumulates artifact digests for a single build run so
+// they can be flushed into a combined checksums.txt once all platforms have
+// finished building.
+var checksumManifest struct {
+	mu      sync.Mutex
+	entries map[string]string // relative path -> "sha256:<hex> sha512:<hex>"
+}
+
+// calculateDigest computes the checksum of path using a pure-Go hash, with no
+// dependency on the sha256sum/shasum binaries being on PATH.
+func calculateDigest(path string, algo digestAlgo) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	var h hash.Hash
+	switch algo {
+	case sha512Algo:
+		h = sha512.New()
+	default:
+		h = sha256.New()
+	}
+
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
+
+// recordChecksum stores both digests for path in the in-memory manifest used
+// by WriteChecksumManifest.
+func recordChecksum(path, sha256Digest string) error {
+	sha512Digest, err := calculateDigest(path, sha512Algo)
+	if err != nil {
+		return err
+	}
+
+	checksumManifest.mu.Lock()
+	defer checksumManifest.mu.Unlock()
+	if checksumManifest.entries == nil {
+		checksumManifest.entries = map[string]string{}
+	}
+	rel, err := filepath.Rel(outputDir, path)
+	if err != nil {
+		rel = path
+	}
+	checksumManifest.entries[rel] = fmt.Sprintf("sha256:%s sha512:%s", sha256Digest, sha512Digest)
+	return nil
+}
+
+// WriteChecksumManifest writes bin/checksums.txt covering every artifact
+// recorded during this build run, one line per file, sorted by path for
+// reproducible diffs.
+func WriteChecksumManifest() error {
+	checksumManifest.mu.Lock()
+	defer checksumManifest.mu.Unlock()
+
+	out := formatChecksumManifest(checksumManifest.entries)
+	return os.WriteFile(filepath.Join(outputDir, "checksums.txt"), []byte(out), 0o644)
+}
+
+// formatChecksumManifest renders entries (relative path -> digest string)
+// as checksums.txt's "<digests>  <path>" lines, sorted by path for
+// reproducible diffs. Split out from WriteChecksumManifest so the
+// formatting can be unit tested without touching the filesystem.
+func formatChecksumManifest(entries map[string]string) string {
+	paths := make([]string, 0, len(entries))
+	for p := range entries {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	var out string
+	for _, p := range paths {
+		out += fmt.Sprintf("%s  %s\n", entries[p], p)
+	}
+	return out
+}
@@ -0,0 +1,91 @@
+//go:build mage
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCalculateDigest(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "artifact.bin")
+	if err := os.WriteFile(path, []byte("rune-forge"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		algo    digestAlgo
+		want    string
+		wantLen int
+	}{
+		{sha256Algo, "b61ac2b269024ae4fe204d2327e90f166214e289d3af0565a04bfc184b724a2a", 64},
+		{sha512Algo, "", 128}, // sha512 checked by length only below
+	}
+
+	for _, c := range cases {
+		got, err := calculateDigest(path, c.algo)
+		if err != nil {
+			t.Fatalf("calculateDigest(%s): %v", c.algo, err)
+		}
+		if len(got) != c.wantLen {
+			t.Errorf("calculateDigest(%s): got length %d, want %d", c.algo, len(got), c.wantLen)
+		}
+		if c.want != "" && got != c.want {
+			t.Errorf("calculateDigest(%s) = %q, want %q", c.algo, got, c.want)
+		}
+	}
+}
+
+func TestCalculateDigestDeterministic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "artifact.bin")
+	if err := os.WriteFile(path, []byte("same bytes every time"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := calculateDigest(path, sha256Algo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := calculateDigest(path, sha256Algo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first != second {
+		t.Errorf("calculateDigest is not deterministic: %q != %q", first, second)
+	}
+}
+
+func TestFormatChecksumManifest(t *testing.T) {
+	cases := []struct {
+		name    string
+		entries map[string]string
+		want    string
+	}{
+		{
+			name:    "empty",
+			entries: map[string]string{},
+			want:    "",
+		},
+		{
+			name: "sorted by path",
+			entries: map[string]string{
+				"linux-amd64/service":  "sha256:bbb sha512:ccc",
+				"darwin-arm64/service": "sha256:aaa sha512:ddd",
+			},
+			want: "sha256:aaa sha512:ddd  darwin-arm64/service\n" +
+				"sha256:bbb sha512:ccc  linux-amd64/service\n",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := formatChecksumManifest(c.entries)
+			if got != c.want {
+				t.Errorf("formatChecksumManifest(%v) = %q, want %q", c.entries, got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+//go:build mage
+
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// githubAnnotate emits a GitHub Actions workflow-command annotation
+// (::error/::warning/::notice) for the given file:line, a no-op outside
+// GitHub Actions (GITHUB_ACTIONS unset).
+func githubAnnotate(level, file string, line int, message string) {
+	if os.Getenv("GITHUB_ACTIONS") != "true" {
+		return
+	}
+	fmt.Printf("::%s file=%s,line=%d::%s\n", level, file, line, message)
+}
+
+// writeGithubJobSummary appends markdown to the step summary shown on the
+// GitHub Actions run page, a no-op outside GitHub Actions
+// (GITHUB_STEP_SUMMARY unset).
+func writeGithubJobSummary(markdown string) error {
+	path := os.Getenv("GITHUB_STEP_SUMMARY")
+	if path == "" {
+		return nil
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.WriteString(markdown + "\n")
+	return err
+}
+
+// PublishRunSummary renders runSummary (see summary.go) as a markdown table
+// and appends it to the GitHub Actions job summary, after WithSummary has
+// populated it.
+func PublishRunSummary() error {
+	md := "## mage run summary\n\n| target | success | duration |\n|---|---|---|\n"
+	for _, r := range runSummary.Results {
+		status := "✅"
+		if !r.Success {
+			status = "❌"
+			githubAnnotate("error", "magefile.go", 0, r.Target+": "+r.Error)
+		}
+		md += fmt.Sprintf("| %s | %s | %s |\n", r.Target, status, r.Duration)
+	}
+	return writeGithubJobSummary(md)
+}
@@ -0,0 +1,58 @@
+//go:build mage
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	azurePipelinesPath = "azure-pipelines.yml"
+	jenkinsfilePath    = "Jenkinsfile"
+)
+
+// GenerateAzurePipelines writes azure-pipelines.yml driving the same mage
+// targets as the GitHub/GitLab generators.
+func GenerateAzurePipelines() error {
+	var steps strings.Builder
+	for _, target := range githubCISteps {
+		fmt.Fprintf(&steps, "  - script: go run github.com/magefile/mage %s\n    displayName: 'mage %s'\n", target, target)
+	}
+
+	content := fmt.Sprintf(`# Generated by "mage generateAzurePipelines" — do not edit by hand.
+trigger:
+  - main
+
+pool:
+  vmImage: ubuntu-latest
+
+steps:
+  - task: GoTool@0
+    inputs:
+      version: '1.22'
+%s`, steps.String())
+	return os.WriteFile(azurePipelinesPath, []byte(content), 0o644)
+}
+
+// GenerateJenkinsfile writes a declarative Jenkinsfile driving the same mage
+// targets as the GitHub/GitLab/Azure generators.
+func GenerateJenkinsfile() error {
+	var stages strings.Builder
+	for _, target := range githubCISteps {
+		fmt.Fprintf(&stages, "        stage('%s') {\n            steps {\n                sh 'go run github.com/magefile/mage %s'\n            }\n        }\n", target, target)
+	}
+
+	content := fmt.Sprintf(`// Generated by "mage generateJenkinsfile" — do not edit by hand.
+pipeline {
+    agent any
+    tools {
+        go '1.22'
+    }
+    stages {
+%s    }
+}
+`, stages.String())
+	return os.WriteFile(jenkinsfilePath, []byte(content), 0o644)
+}
@@ -0,0 +1,50 @@
+//go:build mage
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+const githubWorkflowPath = ".github/workflows/ci.yml"
+
+// githubCISteps lists the mage targets to run, in order, for the generated
+// GitHub Actions CI workflow. The GitLab, Azure Pipelines, and Jenkins
+// generators read the same list.
+var githubCISteps = []string{"lint", "test", "coverage", "scanFS"}
+
+// GenerateGithubActions writes .github/workflows/ci.yml driving the same
+// mage targets a contributor runs locally, so the two never drift apart.
+func GenerateGithubActions() error {
+	if err := os.MkdirAll(".github/workflows", 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(githubWorkflowPath, []byte(renderGithubWorkflow()), 0o644)
+}
+
+func renderGithubWorkflow() string {
+	var steps strings.Builder
+	for _, target := range githubCISteps {
+		fmt.Fprintf(&steps, "      - name: mage %s\n        run: go run github.com/magefile/mage %s\n", target, target)
+	}
+
+	return fmt.Sprintf(`# Generated by "mage generateGithubActions" — do not edit by hand.
+name: CI
+
+on:
+  push:
+    branches: [main]
+  pull_request:
+
+jobs:
+  ci:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - uses: actions/setup-go@v5
+        with:
+          go-version-file: go.mod
+%s`, steps.String())
+}
@@ -0,0 +1,32 @@
+//go:build mage
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+const gitlabCIPath = ".gitlab-ci.yml"
+
+// GenerateGitlabCI writes .gitlab-ci.yml driving the same mage targets as
+// GenerateGithubActions, keeping both providers' pipelines equivalent.
+func GenerateGitlabCI() error {
+	return os.WriteFile(gitlabCIPath, []byte(renderGitlabCI()), 0o644)
+}
+
+func renderGitlabCI() string {
+	var jobs strings.Builder
+	for _, target := range githubCISteps {
+		fmt.Fprintf(&jobs, "%s:\n  stage: ci\n  script:\n    - go run github.com/magefile/mage %s\n\n", target, target)
+	}
+
+	return fmt.Sprintf(`# Generated by "mage generateGitlabCI" — do not edit by hand.
+image: golang:1.22
+
+stages:
+  - ci
+
+%s`, jobs.String())
+}
@@ -0,0 +1,113 @@
+//go:build mage
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/magefile/mage/sh"
+)
+
+const complexityBaseRefEnv = "COMPLEXITY_BASE_REF"
+
+const defaultComplexityThreshold = 15
+
+// Complexity computes cyclomatic (gocyclo) and cognitive (gocognit)
+// complexity for every function in files changed since COMPLEXITY_BASE_REF
+// (default origin/main), failing on any that exceeds
+// cfg.ComplexityThreshold. Scoped to changed files only, so legacy
+// functions that are already over the line don't block unrelated PRs -
+// only new or edited ones are held to the bar.
+func Complexity() error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	threshold := cfg.ComplexityThreshold
+	if threshold == 0 {
+		threshold = defaultComplexityThreshold
+	}
+
+	baseRef := complexityBaseRef()
+	changed, err := changedGoFiles(baseRef)
+	if err != nil {
+		return err
+	}
+	if len(changed) == 0 {
+		Step("Complexity: no changed .go files since %s", baseRef)
+		return nil
+	}
+
+	var violations []string
+	for _, tool := range []string{"gocyclo", "gocognit"} {
+		findings, err := runComplexityTool(tool, changed, threshold)
+		if err != nil {
+			return err
+		}
+		violations = append(violations, findings...)
+	}
+
+	if len(violations) > 0 {
+		return fmt.Errorf("Complexity: %d function(s) over threshold %d:\n  %s",
+			len(violations), threshold, strings.Join(violations, "\n  "))
+	}
+	return nil
+}
+
+func complexityBaseRef() string {
+	if ref := os.Getenv(complexityBaseRefEnv); ref != "" {
+		return ref
+	}
+	return "origin/main"
+}
+
+func changedGoFiles(baseRef string) ([]string, error) {
+	out, err := sh.Output("git", "diff", "--name-only", "--diff-filter=ACM", baseRef+"...HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, f := range strings.Split(out, "\n") {
+		if strings.HasSuffix(f, ".go") {
+			files = append(files, f)
+		}
+	}
+	return files, nil
+}
+
+// runComplexityTool runs gocyclo or gocognit (same CLI output shape:
+// "<score> <pkg> <func> <file>:<line>:<col>") over files, returning every
+// line whose score exceeds threshold.
+func runComplexityTool(tool string, files []string, threshold int) ([]string, error) {
+	args := append([]string{"run", complexityToolModule(tool), "-over", strconv.Itoa(threshold)}, files...)
+	out, err := sh.Output("go", args...)
+	// Both tools exit 0 when nothing is over the threshold and non-zero (with
+	// output) when something is, so an error with no output is a real
+	// tool failure.
+	if err != nil && out == "" {
+		return nil, fmt.Errorf("%s: %w", tool, err)
+	}
+
+	var findings []string
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			findings = append(findings, tool+": "+line)
+		}
+	}
+	return findings, nil
+}
+
+func complexityToolModule(tool string) string {
+	switch tool {
+	case "gocognit":
+		return "github.com/uudashr/gocognit/cmd/gocognit@latest"
+	default:
+		return "github.com/fzipp/gocyclo/cmd/gocyclo@latest"
+	}
+}
@@ -0,0 +1,22 @@
+//go:build mage
+
+package main
+
+import "github.com/magefile/mage/sh"
+
+// DevUp starts the local dev environment (the service plus its dependencies,
+// e.g. postgres/redis) defined in docker-compose.dev.yml.
+func DevUp() error {
+	return sh.RunV(dockerBin(), "compose", "-f", "docker-compose.dev.yml", "up", "-d", "--build")
+}
+
+// DevDown tears down the dev environment started by DevUp, removing
+// volumes so the next DevUp starts from a clean slate.
+func DevDown() error {
+	return sh.RunV(dockerBin(), "compose", "-f", "docker-compose.dev.yml", "down", "-v")
+}
+
+// DevLogs tails logs from every service in the dev environment.
+func DevLogs() error {
+	return sh.RunV(dockerBin(), "compose", "-f", "docker-compose.dev.yml", "logs", "-f")
+}
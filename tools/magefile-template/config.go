@@ -0,0 +1,179 @@
+//go:build mage
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// buildConfig overrides the hard-coded constants at the top of magefile.go.
+// It is loaded once from .rune-forge.yaml (preferred) or magefile.config.json
+// at the repo root; any field left zero keeps its built-in default.
+type buildConfig struct {
+	BinaryName        string            `yaml:"binaryName" json:"binaryName"`
+	MainPath          string            `yaml:"mainPath" json:"mainPath"`
+	OutputDir         string            `yaml:"outputDir" json:"outputDir"`
+	LdflagsVars       map[string]string `yaml:"ldflagsVars" json:"ldflagsVars"`
+	// BuildTags are passed to `go build -tags` as a comma-joined list.
+	BuildTags []string `yaml:"buildTags" json:"buildTags"`
+	CoverageThreshold float64           `yaml:"coverageThreshold" json:"coverageThreshold"`
+	// PackageCoverageThresholds overrides CoverageThreshold for specific
+	// package import paths, e.g. {"github.com/acme/payments/internal/flaky": 40}.
+	PackageCoverageThresholds map[string]float64 `yaml:"packageCoverageThresholds" json:"packageCoverageThresholds"`
+	ToolVersions              map[string]string  `yaml:"toolVersions" json:"toolVersions"`
+	// RequiredEnv lists environment variables that Env:Check enforces are
+	// set, either in the process environment or one of the .env files (see
+	// env.go).
+	RequiredEnv []string `yaml:"requiredEnv" json:"requiredEnv"`
+	// TargetBudgets maps a target name (e.g. "test") to a time.ParseDuration
+	// string; recordMetric (see metrics.go) warns when the target exceeds it.
+	TargetBudgets map[string]string `yaml:"targetBudgets" json:"targetBudgets"`
+	// StaticcheckChecks overrides staticcheck's default -checks flag, e.g.
+	// ["SA1000", "-ST1000"] to enable SA1000 and disable ST1000.
+	StaticcheckChecks []string `yaml:"staticcheckChecks" json:"staticcheckChecks"`
+	// DeadcodeAllowlist exempts specific unused-code findings or go.mod
+	// requirements from failing Deadcode (see deadcode.go).
+	DeadcodeAllowlist []string `yaml:"deadcodeAllowlist" json:"deadcodeAllowlist"`
+	// InternalImportPrefix is passed to goimports -local (see format.go) so
+	// it groups this module's own packages separately from third-party ones.
+	InternalImportPrefix string `yaml:"internalImportPrefix" json:"internalImportPrefix"`
+	// HeaderTemplate is the required leading comment block for every .go
+	// file, enforced by Headers (see headers.go). Empty disables the check.
+	HeaderTemplate string `yaml:"headerTemplate" json:"headerTemplate"`
+	// HeaderExcludeGlobs exempts generated or vendored files from Headers.
+	HeaderExcludeGlobs []string `yaml:"headerExcludeGlobs" json:"headerExcludeGlobs"`
+	// ComplexityThreshold is the max cyclomatic/cognitive complexity score
+	// Complexity allows in a changed function before failing. 0 means use
+	// defaultComplexityThreshold.
+	ComplexityThreshold int `yaml:"complexityThreshold" json:"complexityThreshold"`
+	// SmokeChecks lists the endpoints Smoke hits after boot (see smoke.go).
+	SmokeChecks []smokeCheck `yaml:"smokeChecks" json:"smokeChecks"`
+	// LoadTestURL and LoadTestRequestsPerSecond configure the attack profile
+	// LoadTest runs against the locally started instance (see loadtest.go).
+	LoadTestURL               string `yaml:"loadTestURL" json:"loadTestURL"`
+	LoadTestRequestsPerSecond int    `yaml:"loadTestRequestsPerSecond" json:"loadTestRequestsPerSecond"`
+	// ChaosScenarios are the toxiproxy fault-injection scenarios TestChaos
+	// runs the integration suite under (see chaos.go).
+	ChaosScenarios []chaosScenario `yaml:"chaosScenarios" json:"chaosScenarios"`
+	// ArchRules declares the allowed import relationships between layers,
+	// enforced by ArchCheck (see archcheck.go).
+	ArchRules []archRule `yaml:"archRules" json:"archRules"`
+	// Proxy configures corporate network settings (GOPROXY, GOPRIVATE, a
+	// custom CA bundle) applied to go invocations and checked by Doctor
+	// (see proxy.go).
+	Proxy proxyConfig `yaml:"proxy" json:"proxy"`
+}
+
+const (
+	configFileYAML = ".rune-forge.yaml"
+	configFileJSON = "magefile.config.json"
+)
+
+// loadConfig reads the repo-root config file, if present, applying it on top
+// of the zero-value defaults. It is safe to call when neither file exists.
+func loadConfig() (buildConfig, error) {
+	var cfg buildConfig
+
+	data, path, err := readConfigFile()
+	if err != nil {
+		return cfg, err
+	}
+	if data == nil {
+		return cfg, nil
+	}
+
+	switch path {
+	case configFileYAML:
+		if err := yaml.Unmarshal(data, &cfg); err != nil {
+			return cfg, fmt.Errorf("parsing %s: %w", path, err)
+		}
+	case configFileJSON:
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return cfg, fmt.Errorf("parsing %s: %w", path, err)
+		}
+	}
+
+	if err := cfg.validate(); err != nil {
+		return cfg, fmt.Errorf("%s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+func readConfigFile() (data []byte, path string, err error) {
+	for _, candidate := range []string{configFileYAML, configFileJSON} {
+		data, err = os.ReadFile(candidate)
+		if err == nil {
+			return data, candidate, nil
+		}
+		if !os.IsNotExist(err) {
+			return nil, candidate, err
+		}
+	}
+	return nil, "", nil
+}
+
+// validate returns an actionable error describing the first invalid field,
+// rather than letting a bad config silently fall back to defaults.
+func (c buildConfig) validate() error {
+	if c.CoverageThreshold < 0 || c.CoverageThreshold > 100 {
+		return fmt.Errorf("coverageThreshold must be between 0 and 100, got %g", c.CoverageThreshold)
+	}
+	for tool, version := range c.ToolVersions {
+		if version == "" {
+			return fmt.Errorf("toolVersions.%s must not be empty", tool)
+		}
+	}
+	return nil
+}
+
+// resolvedBinaryName returns cfg.BinaryName, falling back to the built-in
+// default when unset.
+func (c buildConfig) resolvedBinaryName() string {
+	if c.BinaryName != "" {
+		return c.BinaryName
+	}
+	return binaryName
+}
+
+// resolvedMainPath returns cfg.MainPath, falling back to the built-in default
+// when unset.
+func (c buildConfig) resolvedMainPath() string {
+	if c.MainPath != "" {
+		return c.MainPath
+	}
+	return mainPath
+}
+
+// resolvedOutputDir returns cfg.OutputDir, falling back to the built-in
+// default when unset.
+func (c buildConfig) resolvedOutputDir() string {
+	if c.OutputDir != "" {
+		return c.OutputDir
+	}
+	return outputDir
+}
+
+// ldflags renders LdflagsVars as a `go build -ldflags` string of
+// -X pkg.Var=value entries, sorted by key for reproducible builds.
+func (c buildConfig) ldflags() string {
+	if len(c.LdflagsVars) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(c.LdflagsVars))
+	for k := range c.LdflagsVars {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var parts []string
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("-X %s=%s", k, c.LdflagsVars[k]))
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,85 @@
+//go:build mage
+
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// containerImageEnv names the env var used to select the image repo:tag for
+// the Image/Push targets, e.g. IMAGE=ghcr.io/jpoley/rune-forge-service:v1.2.3.
+const containerImageEnv = "IMAGE"
+
+// Image builds a container image for the service with docker (or
+// DOCKER_BIN, if set, for podman/nerdctl compatibility). The image ref comes
+// from the IMAGE env var.
+func Image() error {
+	image, err := requireImageRef()
+	if err != nil {
+		return err
+	}
+	return runv(dockerBin(), "build", "-t", image, ".")
+}
+
+// Push pushes the image built by Image to its registry.
+func Push() error {
+	image, err := requireImageRef()
+	if err != nil {
+		return err
+	}
+	return runv(dockerBin(), "push", image)
+}
+
+func requireImageRef() (string, error) {
+	image := os.Getenv(containerImageEnv)
+	if image == "" {
+		return "", fmt.Errorf("%s must be set, e.g. IMAGE=ghcr.io/org/service:v1.2.3", containerImageEnv)
+	}
+	return image, nil
+}
+
+// multiArchPlatforms is the buildx platform list used by PushMultiArch.
+const multiArchPlatforms = "linux/amd64,linux/arm64"
+
+// PushMultiArch builds and pushes a single multi-arch manifest for IMAGE
+// covering linux/amd64 and linux/arm64 via `docker buildx build --push`, so
+// pullers automatically get the right architecture.
+func PushMultiArch() error {
+	image, err := requireImageRef()
+	if err != nil {
+		return err
+	}
+	return runv(dockerBin(), "buildx", "build",
+		"--platform", multiArchPlatforms,
+		"-t", image,
+		"--push",
+		".")
+}
+
+// AttestImage generates an SBOM and provenance attestation for IMAGE and
+// attaches both to the image manifest via cosign, so `cosign verify-attestation`
+// can confirm what went into the build.
+func AttestImage() error {
+	image, err := requireImageRef()
+	if err != nil {
+		return err
+	}
+
+	sbomPath := outputDir + "/image-sbom.spdx.json"
+	if err := runv("syft", "packages", image, "-o", "spdx-json="+sbomPath); err != nil {
+		return err
+	}
+	if err := runv("cosign", "attest", "--yes", "--type", "spdxjson", "--predicate", sbomPath, image); err != nil {
+		return err
+	}
+	return runv("cosign", "attest", "--yes", "--type", "slsaprovenance",
+		"--predicate", outputDir+"/provenance.json", image)
+}
+
+func dockerBin() string {
+	if bin := os.Getenv("DOCKER_BIN"); bin != "" {
+		return bin
+	}
+	return "docker"
+}
@@ -0,0 +1,95 @@
+//go:build mage
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/magefile/mage/mg"
+	"github.com/magefile/mage/sh"
+)
+
+// pactBrokerURLEnv points at the team's Pact Broker, e.g.
+// https://pact-broker.internal. Both Contract targets require it.
+const pactBrokerURLEnv = "PACT_BROKER_URL"
+
+// Contract groups Pact contract-testing targets.
+type Contract mg.Namespace
+
+// Verify builds and starts the service, waits for e2eHealthURL, then runs
+// provider verification against it for every consumer contract published
+// to the broker, via pact-provider-verifier. No other target calls it;
+// Deploy does not depend on it. Alias: mage contract:verify.
+func (Contract) Verify() error {
+	broker, err := requirePactBroker()
+	if err != nil {
+		return err
+	}
+
+	if err := Build(); err != nil {
+		return err
+	}
+
+	svc := exec.Command(filepath.Join(outputDir, binaryName))
+	svc.Stdout = os.Stdout
+	svc.Stderr = os.Stderr
+	if err := svc.Start(); err != nil {
+		return fmt.Errorf("starting service: %w", err)
+	}
+	defer func() {
+		_ = svc.Process.Kill()
+		_ = svc.Wait()
+	}()
+	if err := waitForHealthy(e2eHealthURL, 30*time.Second); err != nil {
+		return err
+	}
+
+	return sh.RunV("pact-provider-verifier",
+		"--provider-base-url", "http://localhost:8080",
+		"--pact-broker-base-url", broker,
+		"--publish-verification-results",
+		"--provider-app-version", providerVersion())
+}
+
+// Publish publishes this service's consumer contracts (pact/ directory) to
+// the broker, tagged with the current branch, so provider verification
+// elsewhere in the fleet can find them. Alias: mage contract:publish.
+func (Contract) Publish() error {
+	broker, err := requirePactBroker()
+	if err != nil {
+		return err
+	}
+
+	branch, err := sh.Output("git", "rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return err
+	}
+
+	return sh.RunV("pact-broker", "publish", "pact/",
+		"--broker-base-url", broker,
+		"--consumer-app-version", providerVersion(),
+		"--branch", branch)
+}
+
+func requirePactBroker() (string, error) {
+	broker := os.Getenv(pactBrokerURLEnv)
+	if broker == "" {
+		return "", fmt.Errorf("%s must be set to the team's Pact Broker URL", pactBrokerURLEnv)
+	}
+	return broker, nil
+}
+
+// providerVersion identifies this build to the Pact Broker; the current
+// commit SHA, since Pact keys verification results off it rather than a
+// semver tag that may not exist yet for an in-progress branch.
+func providerVersion() string {
+	sha, err := sh.Output("git", "rev-parse", "HEAD")
+	if err != nil {
+		return "unknown"
+	}
+	return sha
+}
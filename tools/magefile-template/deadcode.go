@@ -0,0 +1,103 @@
+//go:build mage
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/magefile/mage/sh"
+)
+
+// Deadcode runs golang.org/x/tools/cmd/deadcode and staticcheck's U-checks
+// (unused code), then cross-checks go.mod requirements against actual
+// imports, failing with everything it found that isn't in
+// cfg.DeadcodeAllowlist. Entries there are package import paths or module
+// paths that are intentionally unused right now (a plugin loaded by path,
+// a tool-only dependency), not a place to silence real findings.
+func Deadcode() error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	allow := map[string]bool{}
+	for _, entry := range cfg.DeadcodeAllowlist {
+		allow[entry] = true
+	}
+
+	var findings []string
+
+	deadFuncs, err := sh.Output("go", "run", "golang.org/x/tools/cmd/deadcode@latest", "./...")
+	if err != nil && deadFuncs == "" {
+		return fmt.Errorf("deadcode: %w", err)
+	}
+	for _, line := range strings.Split(strings.TrimSpace(deadFuncs), "\n") {
+		if line != "" && !allow[line] {
+			findings = append(findings, "unused func: "+line)
+		}
+	}
+
+	unused, err := sh.Output("go", "run", "honnef.co/go/tools/cmd/staticcheck@latest", "-checks", "U1000", "./...")
+	if err != nil && unused == "" {
+		return fmt.Errorf("staticcheck U1000: %w", err)
+	}
+	for _, line := range strings.Split(strings.TrimSpace(unused), "\n") {
+		if line != "" && !allow[line] {
+			findings = append(findings, "unused code: "+line)
+		}
+	}
+
+	unimported, err := unimportedRequirements(allow)
+	if err != nil {
+		return err
+	}
+	findings = append(findings, unimported...)
+
+	if len(findings) > 0 {
+		return fmt.Errorf("Deadcode found %d issue(s):\n  %s", len(findings), strings.Join(findings, "\n  "))
+	}
+	return nil
+}
+
+// unimportedRequirements returns a "go.mod requires X but nothing imports
+// it" line for every direct go.mod requirement that `go mod why` can't find
+// a real import path for.
+func unimportedRequirements(allow map[string]bool) ([]string, error) {
+	data, err := os.ReadFile("go.mod")
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "require ") && !isModuleRequireLine(line) {
+			continue
+		}
+		fields := strings.Fields(strings.TrimPrefix(line, "require "))
+		if len(fields) == 0 || strings.HasSuffix(line, "// indirect") {
+			continue
+		}
+		module := fields[0]
+		if allow[module] {
+			continue
+		}
+
+		why, err := sh.Output("go", "mod", "why", module)
+		if err != nil {
+			continue
+		}
+		if strings.Contains(why, "does not need package") || strings.Contains(why, "(main module does not need") {
+			findings = append(findings, "unused go.mod requirement: "+module)
+		}
+	}
+	return findings, nil
+}
+
+// isModuleRequireLine matches a line inside a `require (...)` block, which
+// has no leading "require" keyword of its own.
+func isModuleRequireLine(line string) bool {
+	fields := strings.Fields(line)
+	return len(fields) >= 2 && strings.Contains(fields[0], "/") && strings.HasPrefix(fields[1], "v")
+}
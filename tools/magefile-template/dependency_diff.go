@@ -0,0 +1,98 @@
+//go:build mage
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/magefile/mage/sh"
+)
+
+// DependencyDiff compares go.sum at HEAD against the merge-base with
+// baseRef (default origin/main) and writes a markdown summary of added,
+// removed, and upgraded modules to bin/dependency-diff.md, suitable for
+// posting as a PR comment.
+func DependencyDiff(baseRef string) error {
+	if baseRef == "" {
+		baseRef = "origin/main"
+	}
+
+	mergeBase, err := sh.Output("git", "merge-base", baseRef, "HEAD")
+	if err != nil {
+		return err
+	}
+
+	before, err := sh.Output("git", "show", mergeBase+":go.sum")
+	if err != nil {
+		before = ""
+	}
+	after, err := os.ReadFile("go.sum")
+	if err != nil {
+		return err
+	}
+
+	report := renderDependencyDiff(parseGoSumModules(before), parseGoSumModules(string(after)))
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(outputDir+"/dependency-diff.md", []byte(report), 0o644)
+}
+
+// parseGoSumModules reduces go.sum content to module -> version, ignoring
+// the /go.mod hash lines since they duplicate the module line's version.
+func parseGoSumModules(goSum string) map[string]string {
+	modules := map[string]string{}
+	for _, line := range splitNonEmptyLines(goSum) {
+		var module, version, hashType string
+		n, err := fmt.Sscanf(line, "%s %s %s", &module, &version, &hashType)
+		if err != nil || n < 2 || strings.HasSuffix(version, "/go.mod") {
+			continue
+		}
+		modules[module] = version
+	}
+	return modules
+}
+
+func splitNonEmptyLines(s string) []string {
+	var lines []string
+	start := 0
+	for i, r := range s {
+		if r == '\n' {
+			if line := s[start:i]; line != "" {
+				lines = append(lines, line)
+			}
+			start = i + 1
+		}
+	}
+	if start < len(s) {
+		lines = append(lines, s[start:])
+	}
+	return lines
+}
+
+func renderDependencyDiff(before, after map[string]string) string {
+	report := "## Dependency changes\n\n| module | before | after |\n|---|---|---|\n"
+	seen := map[string]bool{}
+	for module, afterVersion := range after {
+		seen[module] = true
+		beforeVersion := before[module]
+		if beforeVersion != afterVersion {
+			report += fmt.Sprintf("| %s | %s | %s |\n", module, orNone(beforeVersion), afterVersion)
+		}
+	}
+	for module, beforeVersion := range before {
+		if !seen[module] {
+			report += fmt.Sprintf("| %s | %s | %s |\n", module, beforeVersion, orNone(""))
+		}
+	}
+	return report
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "_(none)_"
+	}
+	return s
+}
@@ -0,0 +1,151 @@
+//go:build mage
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/magefile/mage/sh"
+)
+
+// depGraphOnlyInternalEnv, when set, restricts Deps.Graph to packages under
+// cfg.InternalImportPrefix (see config.go), dropping third-party noise from
+// the rendered graph.
+const depGraphOnlyInternalEnv = "DEPS_GRAPH_INTERNAL_ONLY"
+
+// depGraphRootEnv, when set, restricts Deps.Graph to the given package's
+// transitive dependencies instead of the whole module, e.g.
+// "./internal/handlers".
+const depGraphRootEnv = "DEPS_GRAPH_ROOT"
+
+// Graph builds the package dependency graph with godepgraph, renders it to
+// depgraph.dot/.svg plus an interactive depgraph.html (via d3-graphviz), and
+// fails if it finds an import cycle — `go build` already refuses real
+// cycles, but this also catches cycles confined to _test.go files, which
+// build fine but still signal a layering problem. Alias: mage deps:graph.
+func (Deps) Graph() error {
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		return err
+	}
+
+	root := os.Getenv(depGraphRootEnv)
+	if root == "" {
+		root = "./..."
+	}
+
+	args := []string{"-s"} // -s: omit stdlib packages
+	if os.Getenv(depGraphOnlyInternalEnv) != "" {
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+		if cfg.InternalImportPrefix != "" {
+			args = append(args, "-onlyprefixes", cfg.InternalImportPrefix)
+		}
+	}
+	args = append(args, root)
+
+	dot, err := sh.Output("godepgraph", args...)
+	if err != nil {
+		return fmt.Errorf("godepgraph: %w", err)
+	}
+
+	dotPath := filepath.Join(outputDir, "depgraph.dot")
+	if err := os.WriteFile(dotPath, []byte(dot), 0o644); err != nil {
+		return err
+	}
+
+	if cycle := findImportCycle(dot); cycle != "" {
+		return fmt.Errorf("Deps.Graph: import cycle detected: %s", cycle)
+	}
+
+	svgPath := filepath.Join(outputDir, "depgraph.svg")
+	if err := runv("dot", "-Tsvg", dotPath, "-o", svgPath); err != nil {
+		return fmt.Errorf("rendering svg: %w", err)
+	}
+
+	return writeDepGraphHTML(dot)
+}
+
+// findImportCycle does a DFS over the DOT edge list looking for a back
+// edge; godepgraph emits `"a" -> "b";` lines, which is enough structure to
+// walk without a full DOT parser.
+func findImportCycle(dot string) string {
+	edges := map[string][]string{}
+	for _, line := range strings.Split(dot, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.Contains(line, "->") {
+			continue
+		}
+		parts := strings.SplitN(line, "->", 2)
+		from := strings.Trim(strings.TrimSpace(parts[0]), `";`)
+		to := strings.Trim(strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(parts[1]), ";")), `"`)
+		edges[from] = append(edges[from], to)
+	}
+
+	const (
+		unvisited = 0
+		visiting  = 1
+		done      = 2
+	)
+	state := map[string]int{}
+	var path []string
+
+	var visit func(node string) string
+	visit = func(node string) string {
+		state[node] = visiting
+		path = append(path, node)
+		for _, next := range edges[node] {
+			switch state[next] {
+			case visiting:
+				return strings.Join(append(path, next), " -> ")
+			case unvisited:
+				if cycle := visit(next); cycle != "" {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[node] = done
+		return ""
+	}
+
+	for node := range edges {
+		if state[node] == unvisited {
+			if cycle := visit(node); cycle != "" {
+				return cycle
+			}
+		}
+	}
+	return ""
+}
+
+// depGraphHTMLTemplate embeds the DOT source into a standalone d3-graphviz
+// page so the graph can be explored (pan/zoom/click-to-highlight) without
+// any local tooling beyond a browser.
+const depGraphHTMLTemplate = `<!DOCTYPE html>
+<html>
+<head>
+  <meta charset="utf-8">
+  <title>rune-forge dependency graph</title>
+  <script src="https://unpkg.com/d3@7"></script>
+  <script src="https://unpkg.com/@hpcc-js/wasm@2/dist/graphviz.umd.js"></script>
+  <script src="https://unpkg.com/d3-graphviz@5"></script>
+</head>
+<body>
+  <div id="graph" style="width:100%%;height:100vh;"></div>
+  <script>
+    d3.select("#graph").graphviz().renderDot(%s);
+  </script>
+</body>
+</html>
+`
+
+func writeDepGraphHTML(dot string) error {
+	escaped := strings.ReplaceAll(dot, "`", "' + '`' + '")
+	html := fmt.Sprintf(depGraphHTMLTemplate, "`"+escaped+"`")
+	return os.WriteFile(filepath.Join(outputDir, "depgraph.html"), []byte(html), 0o644)
+}
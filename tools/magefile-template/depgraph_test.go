@@ -0,0 +1,69 @@
+//go:build mage
+
+package main
+
+import "testing"
+
+func TestFindImportCycleNoCycle(t *testing.T) {
+	dot := `digraph G {
+  "a" -> "b";
+  "b" -> "c";
+  "a" -> "c";
+}`
+	if cycle := findImportCycle(dot); cycle != "" {
+		t.Errorf("findImportCycle: got cycle %q, want none", cycle)
+	}
+}
+
+func TestFindImportCycleDirect(t *testing.T) {
+	dot := `digraph G {
+  "a" -> "b";
+  "b" -> "a";
+}`
+	cycle := findImportCycle(dot)
+	if cycle == "" {
+		t.Fatal("findImportCycle: want a cycle, got none")
+	}
+}
+
+func TestFindImportCycleTransitive(t *testing.T) {
+	dot := `digraph G {
+  "a" -> "b";
+  "b" -> "c";
+  "c" -> "a";
+}`
+	cycle := findImportCycle(dot)
+	if cycle == "" {
+		t.Fatal("findImportCycle: want a cycle, got none")
+	}
+}
+
+func TestFindImportCycleSelfLoop(t *testing.T) {
+	dot := `digraph G {
+  "a" -> "a";
+}`
+	cycle := findImportCycle(dot)
+	if cycle == "" {
+		t.Fatal("findImportCycle: want a cycle, got none")
+	}
+}
+
+func TestFindImportCycleDisconnectedComponents(t *testing.T) {
+	// A cycle in one connected component must still be found even when an
+	// earlier, acyclic component is visited first.
+	dot := `digraph G {
+  "x" -> "y";
+  "a" -> "b";
+  "b" -> "a";
+}`
+	cycle := findImportCycle(dot)
+	if cycle == "" {
+		t.Fatal("findImportCycle: want a cycle, got none")
+	}
+}
+
+func TestFindImportCycleEmpty(t *testing.T) {
+	if cycle := findImportCycle("digraph G {\n}"); cycle != "" {
+		t.Errorf("findImportCycle: got cycle %q, want none", cycle)
+	}
+}
@@ -0,0 +1,32 @@
+//go:build mage
+
+package main
+
+import (
+	"os"
+
+	"github.com/magefile/mage/sh"
+)
+
+// OutdatedDeps reports every direct and indirect module with a newer
+// version available, via `go list -u -m all`.
+func OutdatedDeps() error {
+	out, err := sh.Output("go", "list", "-u", "-m", "all")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(outputDir+"/outdated-deps.txt", []byte(out), 0o644)
+}
+
+// UpdateDeps upgrades every module to its latest minor/patch version and
+// tidies go.sum. It deliberately doesn't do major-version bumps
+// automatically, since those can change import paths.
+func UpdateDeps() error {
+	if err := sh.RunV("go", "get", "-u", "./..."); err != nil {
+		return err
+	}
+	return sh.RunV("go", "mod", "tidy")
+}
@@ -0,0 +1,51 @@
+//go:build mage
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/magefile/mage/mg"
+	"github.com/magefile/mage/sh"
+)
+
+// Docs groups documentation-quality targets.
+type Docs mg.Namespace
+
+// Check spell-checks Go source comments and markdown with misspell,
+// validates links in markdown files with lychee, and lints markdown
+// structure with markdownlint-cli, for docs-heavy repos where a typo in a
+// comment or a dead link in the README is worth catching in CI rather than
+// at the next doc review. Like the scanners in security.go, these are
+// shelled out to rather than reimplemented, since all three already exist
+// as mature standalone tools.
+func (Docs) Check() error {
+	var failures []string
+
+	if out, err := sh.Output("misspell", "."); err != nil {
+		if out == "" {
+			return fmt.Errorf("misspell: %w", err)
+		}
+		failures = append(failures, nonEmptyLines(out)...)
+	}
+
+	if out, err := sh.Output("lychee", "--no-progress", "**/*.md"); err != nil {
+		if out == "" {
+			return fmt.Errorf("lychee: %w", err)
+		}
+		failures = append(failures, nonEmptyLines(out)...)
+	}
+
+	if out, err := sh.Output("markdownlint-cli", "**/*.md"); err != nil {
+		if out == "" {
+			return fmt.Errorf("markdownlint: %w", err)
+		}
+		failures = append(failures, nonEmptyLines(out)...)
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("Docs:Check found %d issue(s):\n  %s", len(failures), strings.Join(failures, "\n  "))
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+//go:build mage
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/magefile/mage/sh"
+)
+
+// dryRunEnv, when set to a truthy value, makes runv/runWith print the
+// command (and any env it would add) instead of executing it, so a
+// contributor can audit exactly what `mage ci` or `mage release` is about
+// to do before it touches their machine or a registry.
+const dryRunEnv = "RUNE_DRY_RUN"
+
+func isDryRun() bool {
+	v := os.Getenv(dryRunEnv)
+	return v != "" && v != "0" && v != "false"
+}
+
+// runv runs cmd via sh.RunV, or prints it under RUNE_DRY_RUN.
+func runv(cmd string, args ...string) error {
+	return runWith(nil, cmd, args...)
+}
+
+// runWith runs cmd via sh.RunWith, or prints it (and env) under
+// RUNE_DRY_RUN. Every target that shells out to an external tool
+// (go build, docker, cosign, ...) should go through this instead of calling
+// sh.RunV/sh.RunWith directly, so RUNE_DRY_RUN covers the whole pipeline
+// rather than whichever targets happened to be updated.
+func runWith(env map[string]string, cmd string, args ...string) error {
+	if isDryRun() {
+		fmt.Printf("[dry-run] %s%s\n", envPrefix(env), strings.Join(append([]string{cmd}, args...), " "))
+		return nil
+	}
+	return sh.RunWith(env, cmd, args...)
+}
+
+func envPrefix(env map[string]string) string {
+	if len(env) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, k := range keys {
+		fmt.Fprintf(&b, "%s=%s ", k, env[k])
+	}
+	return b.String()
+}
@@ -0,0 +1,60 @@
+//go:build mage
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/magefile/mage/sh"
+)
+
+// e2eHealthURL is polled after boot to confirm the service is ready before
+// E2ETest runs the suite against it.
+const e2eHealthURL = "http://localhost:8080/healthz"
+
+// E2ETest builds the binary, boots it, waits for it to pass a health check,
+// runs the suite under the `e2e` build tag against it, and always tears the
+// process down afterward.
+func E2ETest() error {
+	if err := Build(); err != nil {
+		return err
+	}
+
+	bin := filepath.Join(outputDir, binaryName)
+	cmd := exec.Command(bin)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("starting %s: %w", bin, err)
+	}
+	defer func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	}()
+
+	if err := waitForHealthy(e2eHealthURL, 30*time.Second); err != nil {
+		return err
+	}
+
+	return sh.RunV("go", "test", "-tags=e2e", "-count=1", "./...")
+}
+
+func waitForHealthy(url string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		resp, err := http.Get(url)
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return nil
+			}
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+	return fmt.Errorf("service at %s did not become healthy within %s", url, timeout)
+}
@@ -0,0 +1,84 @@
+//go:build mage
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/magefile/mage/sh"
+)
+
+// frontendDir is the React (or other JS framework) source tree for the
+// React+Go stack; embedDir is where its production build is copied for
+// Go's embed.FS to pick up (see webFS in the service's own main package).
+const (
+	frontendDir = "frontend"
+	embedDir    = "internal/web/dist"
+)
+
+// Embed builds the frontend and copies its production build into embedDir,
+// then verifies every file embed.FS expects is present, so a broken
+// frontend build fails here instead of surfacing as a 404 in the embedded
+// binary. Build calls this automatically when frontendDir exists (see
+// Build in magefile.go).
+func Embed() error {
+	if _, err := os.Stat(frontendDir); os.IsNotExist(err) {
+		return nil
+	}
+
+	if err := sh.RunV("pnpm", "--dir", frontendDir, "run", "build"); err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(embedDir); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(embedDir), 0o755); err != nil {
+		return err
+	}
+	if err := copyDirTree(filepath.Join(frontendDir, "dist"), embedDir); err != nil {
+		return err
+	}
+
+	return verifyEmbedManifest()
+}
+
+// copyDirTree recursively copies src into dest, creating dest if needed.
+func copyDirTree(src, dest string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dest, rel)
+		if info.IsDir() {
+			return os.MkdirAll(target, 0o755)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(target, data, info.Mode())
+	})
+}
+
+// verifyEmbedManifest fails if embedDir has no index.html, the one file
+// every SPA build must produce and every embed.FS here depends on.
+func verifyEmbedManifest() error {
+	if _, err := os.Stat(filepath.Join(embedDir, "index.html")); os.IsNotExist(err) {
+		return fmt.Errorf("embed: %s has no index.html; did the frontend build succeed?", embedDir)
+	}
+	return nil
+}
+
+// hasFrontend reports whether this module has a frontend/ directory to embed.
+func hasFrontend() bool {
+	info, err := os.Stat(frontendDir)
+	return err == nil && info.IsDir()
+}
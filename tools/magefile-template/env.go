@@ -0,0 +1,146 @@
+//go:build mage
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/magefile/mage/mg"
+)
+
+// envProfileEnv selects which .env.<profile> file loadEnvFiles layers in,
+// e.g. RUNE_ENV_PROFILE=staging. Defaults to "development".
+const envProfileEnv = "RUNE_ENV_PROFILE"
+
+const defaultEnvProfile = "development"
+
+// envFilePrecedence lists the .env files loadEnvFiles reads, in increasing
+// precedence: each later file overrides keys set by an earlier one, and the
+// process environment always wins over all of them.
+func envFilePrecedence(profile string) []string {
+	return []string{
+		".env",
+		fmt.Sprintf(".env.%s", profile),
+		".env.local",
+		fmt.Sprintf(".env.%s.local", profile),
+	}
+}
+
+// loadEnvFiles reads the repo's .env files for the active profile (see
+// envFilePrecedence) and returns the merged result, for use with
+// sh.RunWith. Missing files are skipped; a malformed line is an error since
+// a silently-ignored typo in a KEY=VALUE line is exactly the kind of bug
+// this is meant to catch before it reaches Run/Dev/Test.
+func loadEnvFiles() (map[string]string, error) {
+	profile := os.Getenv(envProfileEnv)
+	if profile == "" {
+		profile = defaultEnvProfile
+	}
+
+	merged := map[string]string{}
+	for _, path := range envFilePrecedence(profile) {
+		vars, err := parseEnvFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		for k, v := range vars {
+			merged[k] = v
+		}
+	}
+	return merged, nil
+}
+
+// parseEnvFile reads a simple KEY=VALUE per line file. Blank lines and
+// lines starting with # are ignored; values are not shell-expanded.
+func parseEnvFile(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	vars := map[string]string{}
+	scanner := bufio.NewScanner(f)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: expected KEY=VALUE, got %q", path, lineNum, line)
+		}
+		vars[strings.TrimSpace(key)] = strings.Trim(strings.TrimSpace(value), `"'`)
+	}
+	return vars, scanner.Err()
+}
+
+// withEnvFiles merges loadEnvFiles's result into env (env's own keys win,
+// so an explicit override at the call site still beats a .env file), for
+// passing to sh.RunWith / exec.Cmd.Env. Used by Run, Dev, and Test so they
+// all see the same profile-aware environment instead of each target
+// re-implementing .env loading.
+func withEnvFiles(env map[string]string) (map[string]string, error) {
+	fileVars, err := loadEnvFiles()
+	if err != nil {
+		return nil, err
+	}
+	if len(fileVars) == 0 {
+		return env, nil
+	}
+
+	merged := map[string]string{}
+	for k, v := range fileVars {
+		if _, set := os.LookupEnv(k); set {
+			continue // the process environment wins over .env files
+		}
+		merged[k] = v
+	}
+	for k, v := range env {
+		merged[k] = v
+	}
+	return merged, nil
+}
+
+// Env groups environment-file targets.
+type Env mg.Namespace
+
+// Check validates that every variable in cfg.RequiredEnv (see config.go) is
+// set, either in the process environment or one of the .env files, failing
+// with the full list of what's missing instead of stopping at the first.
+// Alias: mage env:check.
+func (Env) Check() error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if len(cfg.RequiredEnv) == 0 {
+		return nil
+	}
+
+	fileVars, err := loadEnvFiles()
+	if err != nil {
+		return err
+	}
+
+	var missing []string
+	for _, name := range cfg.RequiredEnv {
+		if os.Getenv(name) != "" {
+			continue
+		}
+		if _, ok := fileVars[name]; ok {
+			continue
+		}
+		missing = append(missing, name)
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing required environment variables: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
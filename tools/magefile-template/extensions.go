@@ -0,0 +1,47 @@
+//go:build mage
+
+package main
+
+// userTargets is the extension point for project-specific targets that
+// don't belong in this shared template: define them in a separate
+// magefile_local.go (gitignored or not, your call) and register them here
+// in an init() so `mage -l` still lists everything in one place. It's
+// pre-seeded with the template's own canonical targets so Alias can resolve
+// both.
+var userTargets = map[string]func() error{
+	"build":   Build,
+	"test":    Test,
+	"release": Release,
+}
+
+// RegisterUserTarget adds a project-specific target under name, for local
+// magefiles to call from their own init(). Panics on a duplicate name so a
+// copy-paste mistake fails fast instead of silently shadowing a target.
+func RegisterUserTarget(name string, fn func() error) {
+	if _, exists := userTargets[name]; exists {
+		panic("magefile: duplicate user target " + name)
+	}
+	userTargets[name] = fn
+}
+
+// targetAliases maps a short alias to the canonical target name, e.g. so
+// `mage b` runs Build. Resolved by Alias.
+var targetAliases = map[string]string{
+	"b": "build",
+	"t": "test",
+	"r": "release",
+}
+
+// Alias runs the target that shortName resolves to via targetAliases, or
+// any target registered directly with RegisterUserTarget.
+func Alias(shortName string) error {
+	if fn, ok := userTargets[shortName]; ok {
+		return fn()
+	}
+	if canonical, ok := targetAliases[shortName]; ok {
+		if fn, ok := userTargets[canonical]; ok {
+			return fn()
+		}
+	}
+	return nil
+}
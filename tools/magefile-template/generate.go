@@ -0,0 +1,56 @@
+//go:build mage
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/magefile/mage/sh"
+)
+
+// generators lists every codegen step Generate and CheckGenerate run, in
+// order. Add new codegen targets (sqlc, graphql, etc.) here rather than
+// wiring up another bespoke Check* target.
+var generators = []struct {
+	name string
+	run  func() error
+}{
+	{"openapi", GenerateOpenAPI},
+	{"mocks", GenerateMocks},
+}
+
+// Generate runs every registered code generator.
+func Generate() error {
+	for _, g := range generators {
+		if err := g.run(); err != nil {
+			return fmt.Errorf("generate %s: %w", g.name, err)
+		}
+	}
+	return nil
+}
+
+// CheckGenerate fails the build if running Generate would change any
+// checked-in file, catching generated output that drifted from its source
+// of truth. It supersedes the narrower CheckMocks for CI use.
+func CheckGenerate() error {
+	before, err := sh.Output("git", "status", "--porcelain")
+	if err != nil {
+		return err
+	}
+	if before != "" {
+		return fmt.Errorf("working tree has uncommitted changes before generation, commit or stash first")
+	}
+
+	if err := Generate(); err != nil {
+		return err
+	}
+
+	after, err := sh.Output("git", "status", "--porcelain")
+	if err != nil {
+		return err
+	}
+	if after != "" {
+		return fmt.Errorf("generated output is stale, run `mage generate` and commit the result:\n%s", after)
+	}
+	return nil
+}
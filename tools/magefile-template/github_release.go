@@ -0,0 +1,64 @@
+//go:build mage
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/magefile/mage/sh"
+)
+
+// PublishGithubRelease creates (or updates) a GitHub release for the tag at
+// HEAD via the gh CLI and uploads every archive/checksum/signature under
+// bin/, clobbering any asset of the same name from a previous run.
+func PublishGithubRelease() error {
+	tag, err := sh.Output("git", "describe", "--tags", "--exact-match", "HEAD")
+	if err != nil {
+		return fmt.Errorf("HEAD is not tagged, run a version bump target first: %w", err)
+	}
+
+	assets, err := releaseAssets()
+	if err != nil {
+		return err
+	}
+	if len(assets) == 0 {
+		return fmt.Errorf("no release assets found under %s, run `mage release` first", outputDir)
+	}
+
+	if err := sh.Run("gh", "release", "view", tag); err == nil {
+		args := append([]string{"release", "upload", tag, "--clobber"}, assets...)
+		return sh.RunV("gh", args...)
+	}
+
+	args := []string{"release", "create", tag, "--generate-notes", "--title", tag}
+	args = append(args, assets...)
+	return sh.RunV("gh", args...)
+}
+
+// releaseAssets returns every archive, checksum, signature, and SBOM under
+// bin/, suitable for passing straight to `gh release create`.
+func releaseAssets() ([]string, error) {
+	var assets []string
+	err := filepath.Walk(outputDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		switch {
+		case strings.HasSuffix(path, ".tar.gz"),
+			strings.HasSuffix(path, ".zip"),
+			strings.HasSuffix(path, ".sig"),
+			strings.HasSuffix(path, ".pem"),
+			strings.HasSuffix(path, ".sbom.json"),
+			filepath.Base(path) == "checksums.txt":
+			assets = append(assets, path)
+		}
+		return nil
+	})
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return assets, err
+}
@@ -0,0 +1,13 @@
+//go:build mage
+
+package main
+
+import "github.com/magefile/mage/sh"
+
+// UpdateGolden re-runs the TestGolden* tests with -update, the convention this
+// template's golden-file tests use to opt into rewriting their .golden
+// fixtures instead of diffing against them. Review the resulting diff before
+// committing.
+func UpdateGolden() error {
+	return sh.RunV("go", "test", "./...", "-run", "TestGolden", "-update")
+}
@@ -0,0 +1,94 @@
+//go:build mage
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/magefile/mage/mg"
+	"github.com/magefile/mage/sh"
+)
+
+// graphqlSchemaPath is the SDL file gqlgen generates code from and
+// GraphQL.CheckBreaking diffs against graphqlSchemaBaselinePath.
+const graphqlSchemaPath = "graph/schema.graphqls"
+
+// graphqlSchemaBaselinePath snapshots the schema as of the last release;
+// update it deliberately via GraphQL.AcceptBaseline, not by hand.
+const graphqlSchemaBaselinePath = "graph/schema-baseline.graphqls"
+
+// graphqlBreakingChangeAckEnv mirrors pendingBumpEnv in apidiff.go: CI sets
+// it when a breaking GraphQL schema change has been deliberately accepted
+// (e.g. the field removal shipped with a deprecation window), so
+// CheckBreaking doesn't have to be disabled wholesale to let it through.
+const graphqlBreakingChangeAckEnv = "GRAPHQL_BREAKING_CHANGE_ACK"
+
+// GraphQL groups schema generation and compatibility targets for the
+// gqlgen-based API.
+type GraphQL mg.Namespace
+
+// Generate runs gqlgen against graphqlSchemaPath, registered in generators
+// (see generate.go) so `mage generate` and CheckGenerate cover it alongside
+// openapi/mocks/sql. Alias: mage graphql:generate.
+func (GraphQL) Generate() error {
+	if _, err := os.Stat(graphqlSchemaPath); os.IsNotExist(err) {
+		return nil
+	}
+	return sh.RunV("go", "run", "github.com/99designs/gqlgen", "generate")
+}
+
+func init() {
+	generators = append(generators, struct {
+		name string
+		run  func() error
+	}{"graphql", func() error { return GraphQL{}.Generate() }})
+}
+
+// Validate lints the schema for structural issues (orphaned types, missing
+// descriptions) independent of any baseline comparison. Alias: mage
+// graphql:validate.
+func (GraphQL) Validate() error {
+	return sh.RunV("graphql-inspector", "validate", graphqlSchemaPath)
+}
+
+// CheckBreaking diffs graphqlSchemaPath against graphqlSchemaBaselinePath
+// and fails on breaking changes (removed fields, narrowed types, etc.)
+// unless graphqlBreakingChangeAckEnv is set, the same acknowledge-to-bypass
+// shape as APICheck uses for Go API breaks. Alias: mage graphql:checkbreaking.
+func (GraphQL) CheckBreaking() error {
+	if _, err := os.Stat(graphqlSchemaBaselinePath); os.IsNotExist(err) {
+		fmt.Printf("GraphQL.CheckBreaking: no baseline at %s yet, run graphql:acceptbaseline\n", graphqlSchemaBaselinePath)
+		return nil
+	}
+
+	report, err := sh.Output("graphql-inspector", "diff", graphqlSchemaBaselinePath, graphqlSchemaPath)
+	// graphql-inspector exits non-zero when it finds breaking changes;
+	// that's the signal being checked for, not necessarily a tool failure.
+	if err != nil && report == "" {
+		return fmt.Errorf("graphql-inspector diff: %w", err)
+	}
+	if report == "" {
+		return nil
+	}
+
+	if os.Getenv(graphqlBreakingChangeAckEnv) != "" {
+		fmt.Printf("GraphQL.CheckBreaking: breaking changes found, but %s allows them:\n%s\n", graphqlBreakingChangeAckEnv, report)
+		return nil
+	}
+
+	return fmt.Errorf("GraphQL.CheckBreaking: breaking schema changes found, set %s to ship anyway:\n%s",
+		graphqlBreakingChangeAckEnv, report)
+}
+
+// AcceptBaseline copies the current schema over graphqlSchemaBaselinePath,
+// deliberately resetting the breaking-change comparison point after a
+// release. Run this as part of cutting a release, not ad hoc. Alias: mage
+// graphql:acceptbaseline.
+func (GraphQL) AcceptBaseline() error {
+	data, err := os.ReadFile(graphqlSchemaPath)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", graphqlSchemaPath, err)
+	}
+	return os.WriteFile(graphqlSchemaBaselinePath, data, 0o644)
+}
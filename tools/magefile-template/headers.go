@@ -0,0 +1,91 @@
+//go:build mage
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Headers checks every .go file for cfg.HeaderTemplate (see config.go),
+// skipping cfg.HeaderExcludeGlobs (generated code, vendored files), and
+// fails listing every file that's missing it or has a stale one. Legal
+// review flags this on every release, so it runs as part of CI rather than
+// being a manual checklist item.
+func Headers() error {
+	return walkHeaders(false)
+}
+
+// HeadersFix inserts or updates the header in every file Headers would
+// otherwise fail on.
+func HeadersFix() error {
+	return walkHeaders(true)
+}
+
+func walkHeaders(fix bool) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if cfg.HeaderTemplate == "" {
+		return nil
+	}
+	header := strings.TrimRight(cfg.HeaderTemplate, "\n") + "\n"
+
+	var missing []string
+	err = filepath.Walk(".", func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+		if headerExcluded(path, cfg.HeaderExcludeGlobs) {
+			return nil
+		}
+
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if strings.HasPrefix(string(contents), header) {
+			return nil
+		}
+
+		if !fix {
+			missing = append(missing, path)
+			return nil
+		}
+		return os.WriteFile(path, append([]byte(header), stripStaleHeader(contents)...), info.Mode())
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("Headers: missing or stale license header in:\n  %s", strings.Join(missing, "\n  "))
+	}
+	return nil
+}
+
+// stripStaleHeader drops a leading comment block so HeadersFix replaces an
+// out-of-date header instead of stacking a new one above it.
+func stripStaleHeader(contents []byte) []byte {
+	lines := strings.SplitAfter(string(contents), "\n")
+	i := 0
+	for i < len(lines) && (strings.HasPrefix(lines[i], "//") || strings.TrimSpace(lines[i]) == "") {
+		i++
+	}
+	return []byte(strings.Join(lines[i:], ""))
+}
+
+func headerExcluded(path string, globs []string) bool {
+	for _, glob := range globs {
+		if ok, _ := filepath.Match(glob, path); ok {
+			return true
+		}
+	}
+	return false
+}
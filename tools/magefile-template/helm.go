@@ -0,0 +1,46 @@
+//go:build mage
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/magefile/mage/sh"
+)
+
+const helmChartDir = "deploy/helm"
+
+// PackageHelmChart lints then packages the Helm chart in deploy/helm into
+// bin/, bumping the chart's appVersion to the current git tag first so the
+// package always reflects what it's shipping.
+func PackageHelmChart() error {
+	version, err := sh.Output("git", "describe", "--tags", "--abbrev=0")
+	if err != nil {
+		return fmt.Errorf("no tag to stamp as appVersion: %w", err)
+	}
+
+	if err := sh.RunV("helm", "lint", helmChartDir); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		return err
+	}
+	return sh.RunV("helm", "package", helmChartDir,
+		"--app-version", version,
+		"--version", versionWithoutV(version),
+		"--destination", outputDir)
+}
+
+// TemplateHelmChart renders the chart with the given values file, for
+// reviewing generated manifests without a live cluster.
+func TemplateHelmChart(valuesFile string) error {
+	return sh.RunV("helm", "template", binaryName, helmChartDir, "-f", valuesFile)
+}
+
+func versionWithoutV(tag string) string {
+	if len(tag) > 0 && tag[0] == 'v' {
+		return tag[1:]
+	}
+	return tag
+}
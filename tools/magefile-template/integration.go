@@ -0,0 +1,16 @@
+//go:build mage
+
+package main
+
+import "github.com/magefile/mage/sh"
+
+// IntegrationTest runs the integration suite (files behind the
+// `integration` build tag). Those tests are expected to manage their own
+// testcontainers lifecycle via testify suite setup/teardown; this target
+// just sets TESTCONTAINERS_RYUK_DISABLED=false so orphaned containers are
+// still reaped if a run is killed mid-suite.
+func IntegrationTest() error {
+	return sh.RunWith(map[string]string{
+		"TESTCONTAINERS_RYUK_DISABLED": "false",
+	}, "go", "test", "-tags=integration", "-count=1", "./...")
+}
@@ -0,0 +1,64 @@
+//go:build mage
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/magefile/mage/sh"
+)
+
+// k8sNamespaceEnv/k8sContextEnv select where Deploy and its rollout check
+// target. Both default to the kubeconfig's current context/namespace when
+// unset.
+const (
+	k8sNamespaceEnv = "K8S_NAMESPACE"
+	k8sContextEnv   = "K8S_CONTEXT"
+)
+
+// Deploy upgrades (or installs) the Helm release for this service and waits
+// for the rollout to complete, rolling back automatically if it doesn't.
+func Deploy() error {
+	args := []string{"upgrade", "--install", binaryName, helmChartDir, "--wait", "--atomic", "--timeout", "5m"}
+	args = append(args, k8sTargetFlags()...)
+
+	if err := sh.RunV("helm", args...); err != nil {
+		return err
+	}
+	return verifyRollout()
+}
+
+func verifyRollout() error {
+	args := append([]string{"rollout", "status", "deployment/" + binaryName}, kubectlTargetFlags()...)
+	if err := sh.RunV("kubectl", args...); err != nil {
+		rollbackArgs := append([]string{"rollback", binaryName}, k8sTargetFlags()...)
+		if rbErr := sh.RunV("helm", rollbackArgs...); rbErr != nil {
+			return fmt.Errorf("rollout failed and rollback also failed: %v (rollback: %v)", err, rbErr)
+		}
+		return fmt.Errorf("rollout failed, rolled back: %w", err)
+	}
+	return nil
+}
+
+func k8sTargetFlags() []string {
+	var flags []string
+	if ns := os.Getenv(k8sNamespaceEnv); ns != "" {
+		flags = append(flags, "--namespace", ns)
+	}
+	if ctx := os.Getenv(k8sContextEnv); ctx != "" {
+		flags = append(flags, "--kube-context", ctx)
+	}
+	return flags
+}
+
+func kubectlTargetFlags() []string {
+	var flags []string
+	if ns := os.Getenv(k8sNamespaceEnv); ns != "" {
+		flags = append(flags, "--namespace", ns)
+	}
+	if ctx := os.Getenv(k8sContextEnv); ctx != "" {
+		flags = append(flags, "--context", ctx)
+	}
+	return flags
+}
@@ -0,0 +1,53 @@
+//go:build mage
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/magefile/mage/sh"
+)
+
+const kustomizeOverlaysDir = "deploy/overlays"
+
+// RenderKustomize renders the named overlay (e.g. "staging", "production")
+// under deploy/overlays and prints the resulting manifests to stdout.
+func RenderKustomize(overlay string) error {
+	return sh.RunV("kubectl", "kustomize", filepath.Join(kustomizeOverlaysDir, overlay))
+}
+
+// ValidateKustomize renders every overlay under deploy/overlays and pipes
+// the output through kubeconform to catch schema errors before they reach a
+// cluster.
+func ValidateKustomize() error {
+	entries, err := os.ReadDir(kustomizeOverlaysDir)
+	if err != nil {
+		return fmt.Errorf("listing %s: %w", kustomizeOverlaysDir, err)
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		rendered, err := sh.Output("kubectl", "kustomize", filepath.Join(kustomizeOverlaysDir, entry.Name()))
+		if err != nil {
+			return fmt.Errorf("rendering overlay %s: %w", entry.Name(), err)
+		}
+		if err := validateManifests(rendered); err != nil {
+			return fmt.Errorf("validating overlay %s: %w", entry.Name(), err)
+		}
+	}
+	return nil
+}
+
+func validateManifests(manifests string) error {
+	cmd := exec.Command("kubeconform", "-summary", "-")
+	cmd.Stdin = strings.NewReader(manifests)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
@@ -0,0 +1,57 @@
+//go:build mage
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/magefile/mage/sh"
+)
+
+// licensePolicy lists SPDX identifiers explicitly allowed or denied for
+// dependencies. A license that is neither allowed nor denied is treated as
+// denied, so adding a new dependency with an unreviewed license fails
+// closed rather than silently passing.
+var licensePolicy = struct {
+	allow []string
+	deny  []string
+}{
+	allow: []string{"MIT", "Apache-2.0", "BSD-2-Clause", "BSD-3-Clause", "ISC"},
+	deny:  []string{"GPL-2.0", "GPL-3.0", "AGPL-3.0"},
+}
+
+// ScanLicenses runs go-licenses against every dependency and fails the
+// build if any license falls outside licensePolicy.allow.
+func ScanLicenses() error {
+	out, err := sh.Output("go-licenses", "csv", "./...")
+	if err != nil {
+		return err
+	}
+
+	var violations []string
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		fields := strings.Split(line, ",")
+		if len(fields) < 3 {
+			continue
+		}
+		pkg, license := fields[0], fields[2]
+		if contains(licensePolicy.deny, license) || !contains(licensePolicy.allow, license) {
+			violations = append(violations, fmt.Sprintf("%s: %s", pkg, license))
+		}
+	}
+
+	if len(violations) > 0 {
+		return fmt.Errorf("disallowed dependency licenses:\n  %s", strings.Join(violations, "\n  "))
+	}
+	return nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+//go:build mage
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	vegeta "github.com/tsenart/vegeta/v12/lib"
+)
+
+// loadTestBaselinePath stores the SLO this module is held to; update it
+// deliberately (not by re-running LoadTest blind) when a perf improvement
+// or an accepted regression changes the baseline.
+const loadTestBaselinePath = "loadtest-baseline.json"
+
+// loadTestSLO is the stored baseline LoadTest compares a run against.
+type loadTestSLO struct {
+	P50MS       float64 `json:"p50Ms"`
+	P99MS       float64 `json:"p99Ms"`
+	SuccessRate float64 `json:"successRate"`
+}
+
+// LoadTest runs a load profile against the locally started instance with
+// vegeta's library (no external binary dependency, unlike k6), records
+// latency percentiles and throughput, and fails if p99 latency rises more
+// than 20% or success rate falls more than 1% from the stored SLO baseline.
+func LoadTest() error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	targeter := vegeta.NewStaticTargeter(vegeta.Target{
+		Method: "GET",
+		URL:    cfg.LoadTestURL,
+	})
+	rate := vegeta.Rate{Freq: cfg.LoadTestRequestsPerSecond, Per: time.Second}
+	if rate.Freq == 0 {
+		rate.Freq = 50
+	}
+	duration := 30 * time.Second
+
+	attacker := vegeta.NewAttacker()
+	var metrics vegeta.Metrics
+	for res := range attacker.Attack(targeter, rate, duration, "loadtest") {
+		metrics.Add(res)
+	}
+	metrics.Close()
+
+	fmt.Printf("LoadTest: p50=%s p99=%s success=%.2f%% throughput=%.1f/s\n",
+		metrics.Latencies.P50, metrics.Latencies.P99, metrics.Success*100, metrics.Throughput)
+
+	baseline, err := loadTestBaseline()
+	if err != nil {
+		return err
+	}
+	if baseline == nil {
+		return writeLoadTestBaseline(metrics)
+	}
+
+	return compareLoadTestToBaseline(metrics, *baseline)
+}
+
+func loadTestBaseline() (*loadTestSLO, error) {
+	data, err := os.ReadFile(loadTestBaselinePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var slo loadTestSLO
+	if err := json.Unmarshal(data, &slo); err != nil {
+		return nil, err
+	}
+	return &slo, nil
+}
+
+func writeLoadTestBaseline(metrics vegeta.Metrics) error {
+	slo := loadTestSLO{
+		P50MS:       float64(metrics.Latencies.P50.Milliseconds()),
+		P99MS:       float64(metrics.Latencies.P99.Milliseconds()),
+		SuccessRate: metrics.Success,
+	}
+	data, err := json.MarshalIndent(slo, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Printf("LoadTest: no baseline found, writing one to %s\n", loadTestBaselinePath)
+	return os.WriteFile(loadTestBaselinePath, data, 0o644)
+}
+
+func compareLoadTestToBaseline(metrics vegeta.Metrics, baseline loadTestSLO) error {
+	p99MS := float64(metrics.Latencies.P99.Milliseconds())
+	if p99MS > baseline.P99MS*1.2 {
+		return fmt.Errorf("LoadTest: p99 latency %gms regressed more than 20%% over baseline %gms", p99MS, baseline.P99MS)
+	}
+	if metrics.Success < baseline.SuccessRate*0.99 {
+		return fmt.Errorf("LoadTest: success rate %.4f regressed below baseline %.4f", metrics.Success, baseline.SuccessRate)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+//go:build mage
+
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// logLevelEnv selects how chatty Info/Debug are, e.g. RUNE_LOG_LEVEL=debug.
+// Levels, from quietest to loudest: quiet, normal (default), verbose, debug.
+const logLevelEnv = "RUNE_LOG_LEVEL"
+
+type logLevel int
+
+const (
+	logQuiet logLevel = iota
+	logNormal
+	logVerbose
+	logDebug
+)
+
+func currentLogLevel() logLevel {
+	switch os.Getenv(logLevelEnv) {
+	case "quiet":
+		return logQuiet
+	case "verbose":
+		return logVerbose
+	case "debug":
+		return logDebug
+	default:
+		return logNormal
+	}
+}
+
+// colorEnabled reports whether ANSI color/emoji should be emitted: off when
+// NO_COLOR is set (https://no-color.org) or stdout isn't a terminal, so CI
+// logs stay plain text.
+func colorEnabled() bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	info, err := os.Stdout.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+func colorize(code, text string) string {
+	if !colorEnabled() {
+		return text
+	}
+	return "\033[" + code + "m" + text + "\033[0m"
+}
+
+// Step announces the start of a target at normal verbosity and above, e.g.
+// "==> build". Replaces the ad hoc fmt.Printf("==> ...") calls scattered
+// through the template.
+func Step(format string, args ...any) {
+	if currentLogLevel() < logNormal {
+		return
+	}
+	fmt.Println(colorize("36", "==> "+fmt.Sprintf(format, args...)))
+}
+
+// Verbosef logs at verbose level and above, for detail a normal run doesn't
+// need to see but is useful when narrowing down a failure.
+func Verbosef(format string, args ...any) {
+	if currentLogLevel() < logVerbose {
+		return
+	}
+	fmt.Println(fmt.Sprintf(format, args...))
+}
+
+// Debugf logs at debug level only.
+func Debugf(format string, args ...any) {
+	if currentLogLevel() < logDebug {
+		return
+	}
+	fmt.Println(colorize("90", fmt.Sprintf(format, args...)))
+}
+
+// Warnf always prints, in yellow when color is enabled.
+func Warnf(format string, args ...any) {
+	fmt.Println(colorize("33", "warning: "+fmt.Sprintf(format, args...)))
+}
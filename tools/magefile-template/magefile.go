@@ -0,0 +1,149 @@
+//go:build mage
+
+// Package main is the mage build pipeline for services scaffolded from this
+// template. See README.md for how to adopt it in a new module.
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+const (
+	binaryName = "service"
+	mainPath   = "./cmd/service"
+	outputDir  = "bin"
+)
+
+// platform is a single GOOS/GOARCH pair to cross-compile for.
+type platform struct {
+	os   string
+	arch string
+}
+
+// crossCompileTargets is the default matrix used by BuildAll.
+var crossCompileTargets = []platform{
+	{"linux", "amd64"},
+	{"linux", "arm64"},
+	{"darwin", "amd64"},
+	{"darwin", "arm64"},
+	{"windows", "amd64"},
+}
+
+// Build compiles the binary for the host GOOS/GOARCH into bin/. Constants may
+// be overridden by a .rune-forge.yaml or magefile.config.json at repo root;
+// see config.go. When frontendDir exists, it builds and embeds the frontend
+// first via Embed (see embed.go) so the binary always ships the version of
+// the UI it was built alongside.
+func Build() error {
+	if hasFrontend() {
+		if err := Embed(); err != nil {
+			return err
+		}
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	out := filepath.Join(cfg.resolvedOutputDir(), cfg.resolvedBinaryName())
+	if runtime.GOOS == "windows" {
+		out += ".exe"
+	}
+
+	args := []string{"build", "-o", out}
+	if hasVendorDir() {
+		args = append(args, "-mod=vendor")
+	}
+	if tags := cfg.BuildTags; len(tags) > 0 {
+		args = append(args, "-tags", strings.Join(tags, ","))
+	}
+	if ldflags := cfg.ldflags(); ldflags != "" {
+		args = append(args, "-ldflags", ldflags)
+	}
+	args = append(args, cfg.resolvedMainPath())
+
+	return runWith(cfg.withProxyEnv(withRemoteCache(nil)), "go", args...)
+}
+
+// Clean removes build artifacts.
+func Clean() error {
+	return os.RemoveAll(outputDir)
+}
+
+// BuildAll cross-compiles the binary for every platform in
+// crossCompileTargets in parallel, writing each artifact to
+// bin/<os>-<arch>/<binaryName>[.exe] alongside a per-platform SHA256 file.
+func BuildAll() error {
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		return err
+	}
+
+	var (
+		wg      sync.WaitGroup
+		errs    = make([]error, len(crossCompileTargets))
+		results = crossCompileTargets
+	)
+
+	for i, p := range results {
+		wg.Add(1)
+		go func(i int, p platform) {
+			defer wg.Done()
+			errs[i] = buildPlatform(p)
+		}(i, p)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return fmt.Errorf("build %s/%s: %w", results[i].os, results[i].arch, err)
+		}
+	}
+	return WriteChecksumManifest()
+}
+
+// CrossCompile is an alias for BuildAll kept for readability in CI configs.
+func CrossCompile() error {
+	return BuildAll()
+}
+
+func buildPlatform(p platform) error {
+	dir := filepath.Join(outputDir, p.os+"-"+p.arch)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	name := binaryName
+	if p.os == "windows" {
+		name += ".exe"
+	}
+	out := filepath.Join(dir, name)
+
+	if isDryRun() {
+		fmt.Printf("[dry-run] GOOS=%s GOARCH=%s CGO_ENABLED=0 go build -o %s %s\n", p.os, p.arch, out, mainPath)
+		return nil
+	}
+
+	cmd := exec.Command("go", "build", "-o", out, mainPath)
+	cmd.Env = append(os.Environ(), "GOOS="+p.os, "GOARCH="+p.arch, "CGO_ENABLED=0")
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return err
+	}
+
+	digest, err := calculateDigest(out, sha256Algo)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(out+".sha256", []byte(digest+"  "+name+"\n"), 0o644); err != nil {
+		return err
+	}
+	return recordChecksum(out, digest)
+}
@@ -0,0 +1,128 @@
+//go:build mage
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	buildMetricsPath  = "bin/build-metrics.json"
+	buildTimelinePath = "bin/build-timeline.html"
+)
+
+// targetMetric is one target's timing, as recorded by recordMetric.
+type targetMetric struct {
+	Target     string    `json:"target"`
+	StartedAt  time.Time `json:"startedAt"`
+	DurationMS int64     `json:"durationMs"`
+	OverBudget bool      `json:"overBudget,omitempty"`
+	BudgetMS   int64     `json:"budgetMs,omitempty"`
+}
+
+// buildMetrics accumulates every recordMetric call in a single mage
+// invocation, across possibly-repeated target names (e.g. Build called
+// once directly and once as part of Release).
+var buildMetrics struct {
+	Targets []targetMetric `json:"targets"`
+}
+
+// recordMetric appends target's timing to buildMetrics and warns via Warnf
+// when it exceeds the budget configured for it in targetBudgets (see
+// config.go's TargetBudgets), so a target that's crept from 10s to 90s gets
+// flagged instead of just slowly eating everyone's CI minutes.
+func recordMetric(target string, start time.Time, duration time.Duration) {
+	metric := targetMetric{
+		Target:     target,
+		StartedAt:  start,
+		DurationMS: duration.Milliseconds(),
+	}
+
+	if budget, ok := targetBudget(target); ok {
+		metric.BudgetMS = budget.Milliseconds()
+		if duration > budget {
+			metric.OverBudget = true
+			Warnf("target %q took %s, over its %s budget", target, duration, budget)
+		}
+	}
+
+	buildMetrics.Targets = append(buildMetrics.Targets, metric)
+}
+
+// targetBudget looks up target's budget from the repo config, if any.
+func targetBudget(target string) (time.Duration, bool) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return 0, false
+	}
+	raw, ok := cfg.TargetBudgets[target]
+	if !ok {
+		return 0, false
+	}
+	budget, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, false
+	}
+	return budget, true
+}
+
+func flushBuildMetrics() error {
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(buildMetrics, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(buildMetricsPath, data, 0o644); err != nil {
+		return err
+	}
+	return os.WriteFile(buildTimelinePath, []byte(renderTimelineHTML()), 0o644)
+}
+
+// renderTimelineHTML renders buildMetrics as a minimal flamegraph-style
+// timeline: one bar per target, width proportional to duration, positioned
+// by start offset from the first target. No JS dependency, so it opens
+// straight from a CI artifact download.
+func renderTimelineHTML() string {
+	var b strings.Builder
+	b.WriteString("<!doctype html><html><head><meta charset=\"utf-8\"><title>build timeline</title>")
+	b.WriteString("<style>body{font:13px monospace;margin:2em} .bar{height:1.4em;background:#3b82f6;color:#fff;white-space:nowrap;overflow:hidden;margin:2px 0} .over{background:#dc2626}</style>")
+	b.WriteString("</head><body>")
+
+	if len(buildMetrics.Targets) == 0 {
+		b.WriteString("<p>no targets recorded</p></body></html>")
+		return b.String()
+	}
+
+	earliest := buildMetrics.Targets[0].StartedAt
+	for _, m := range buildMetrics.Targets {
+		if m.StartedAt.Before(earliest) {
+			earliest = m.StartedAt
+		}
+	}
+
+	for _, m := range buildMetrics.Targets {
+		offsetMS := m.StartedAt.Sub(earliest).Milliseconds()
+		class := "bar"
+		if m.OverBudget {
+			class = "bar over"
+		}
+		fmt.Fprintf(&b, "<div style=\"margin-left:%dpx;width:%dpx\" class=\"%s\">%s (%dms)</div>\n",
+			offsetMS/5, max64(m.DurationMS/5, 40), class, m.Target, m.DurationMS)
+	}
+
+	b.WriteString("</body></html>")
+	return b.String()
+}
+
+func max64(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
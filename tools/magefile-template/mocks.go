@@ -0,0 +1,41 @@
+//go:build mage
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/magefile/mage/sh"
+)
+
+// GenerateMocks regenerates mockery-based mocks for every interface
+// annotated with `//go:generate mockery`.
+func GenerateMocks() error {
+	return sh.RunV("mockery", "--config", ".mockery.yaml")
+}
+
+// CheckMocks fails the build if regenerating mocks would change any
+// checked-in file, catching the case where an interface changed but
+// GenerateMocks wasn't re-run before committing.
+func CheckMocks() error {
+	before, err := sh.Output("git", "status", "--porcelain", "--", "**/mocks/")
+	if err != nil {
+		return err
+	}
+	if before != "" {
+		return fmt.Errorf("mocks directory has uncommitted changes before generation, commit or stash first")
+	}
+
+	if err := GenerateMocks(); err != nil {
+		return err
+	}
+
+	after, err := sh.Output("git", "status", "--porcelain", "--", "**/mocks/")
+	if err != nil {
+		return err
+	}
+	if after != "" {
+		return fmt.Errorf("mocks are stale, run `mage generateMocks` and commit the result:\n%s", after)
+	}
+	return nil
+}
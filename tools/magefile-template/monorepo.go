@@ -0,0 +1,64 @@
+//go:build mage
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// modulesRootEnv points at the directory containing sibling modules, each
+// with its own magefile.go (e.g. this repo's tools/ directory). Defaults to
+// the parent of the current module.
+const modulesRootEnv = "MAGE_MODULES_ROOT"
+
+// AllModules runs the named target (e.g. "test", "lint") in this module and
+// every sibling module under modulesRoot that has its own magefile.go, so a
+// single `mage allModules test` exercises the whole monorepo. Usage:
+// `mage allModules <target>`.
+func AllModules(target string) error {
+	modules, err := discoverModules()
+	if err != nil {
+		return err
+	}
+	return runTargetInModules(target, modules)
+}
+
+// runTargetInModules invokes `go run github.com/magefile/mage <target>` in
+// each of dirs, in order, stopping at the first failure.
+func runTargetInModules(target string, dirs []string) error {
+	for _, dir := range dirs {
+		Step("%s: mage %s", dir, target)
+		cmd := exec.Command("go", "run", "github.com/magefile/mage", target)
+		cmd.Dir = dir
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("%s: %w", dir, err)
+		}
+	}
+	return nil
+}
+
+// discoverModules returns every directory under modulesRoot containing a
+// magefile.go, sorted for reproducible ordering.
+func discoverModules() ([]string, error) {
+	root := os.Getenv(modulesRootEnv)
+	if root == "" {
+		root = ".."
+	}
+
+	var modules []string
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && filepath.Base(path) == "magefile.go" {
+			modules = append(modules, filepath.Dir(path))
+		}
+		return nil
+	})
+	return modules, err
+}
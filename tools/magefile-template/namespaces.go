@@ -0,0 +1,74 @@
+//go:build mage
+
+package main
+
+import "github.com/magefile/mage/mg"
+
+// This file groups related target functions into mg.Namespace types
+// (`mage security:fs`, `mage deps:outdated`, ...) for discoverability now that
+// the flat target list has grown past what `mage -l` presents usefully.
+// Build and Test stay flat top-level targets (`mage build`, `mage test`)
+// since they're the two most frequently invoked and a namespace type named
+// Build or Test would collide with those existing function identifiers.
+// The underlying functions keep their original behavior; these are thin
+// delegates, not a rewrite.
+
+// Security groups vulnerability/compliance scanning targets.
+type Security mg.Namespace
+
+// FS scans the filesystem for vulnerabilities. Alias: mage security:fs.
+func (Security) FS() error { return ScanFS() }
+
+// Image scans the container image for vulnerabilities. Alias: mage security:image.
+func (Security) Image() error { return ScanImage() }
+
+// Vulnerabilities runs govulncheck and osv-scanner. Alias: mage security:vulnerabilities.
+func (Security) Vulnerabilities() error { return ScanVulnerabilities() }
+
+// Secrets scans git history for committed credentials. Alias: mage security:secrets.
+func (Security) Secrets() error { return ScanSecrets() }
+
+// Licenses enforces the dependency license policy. Alias: mage security:licenses.
+func (Security) Licenses() error { return ScanLicenses() }
+
+// Container groups image build/publish/attest targets.
+type Container mg.Namespace
+
+// Build builds the container image. Alias: mage container:build.
+func (Container) Build() error { return Image() }
+
+// Push pushes the container image. Alias: mage container:push.
+func (Container) Push() error { return Push() }
+
+// PushMultiArch pushes a multi-arch manifest. Alias: mage container:pushmultiarch.
+func (Container) PushMultiArch() error { return PushMultiArch() }
+
+// Attest attaches SBOM and provenance attestations. Alias: mage container:attest.
+func (Container) Attest() error { return AttestImage() }
+
+// Hooks groups git hook installation targets.
+type Hooks mg.Namespace
+
+// Install writes the repo's git hooks and points git at them. Alias: mage hooks:install.
+func (Hooks) Install() error { return installHooks() }
+
+// Deps groups dependency-maintenance targets.
+type Deps mg.Namespace
+
+// Outdated reports modules with a newer version available. Alias: mage deps:outdated.
+func (Deps) Outdated() error { return OutdatedDeps() }
+
+// Update upgrades every module and tidies go.sum. Alias: mage deps:update.
+func (Deps) Update() error { return UpdateDeps() }
+
+// Install downloads module dependencies, across every go.work module when
+// one exists. Alias: mage deps:install.
+func (Deps) Install() error { return InstallDeps() }
+
+// Tidy runs `go mod tidy`, across every go.work module when one exists.
+// Alias: mage deps:tidy.
+func (Deps) Tidy() error { return Tidy() }
+
+// Verify runs `go mod verify` plus WorkspaceDepsCheck across every go.work
+// module when one exists. Alias: mage deps:verify.
+func (Deps) Verify() error { return Verify() }
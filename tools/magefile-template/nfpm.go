@@ -0,0 +1,21 @@
+//go:build mage
+
+package main
+
+import "github.com/magefile/mage/sh"
+
+// nfpmConfigPath describes the package metadata (maintainer, description,
+// files) consumed by nfpm; see https://nfpm.goreleaser.com.
+const nfpmConfigPath = "nfpm.yaml"
+
+// PackageLinux builds .deb, .rpm, and .apk packages from the host-arch
+// binary in bin/ using nfpm, writing them to bin/packages/.
+func PackageLinux() error {
+	for _, format := range []string{"deb", "rpm", "apk"} {
+		if err := sh.RunV("nfpm", "package", "--config", nfpmConfigPath,
+			"--packager", format, "--target", outputDir+"/packages/"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+//go:build mage
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/magefile/mage/mg"
+)
+
+// notifySeverity classifies a Notify call so routing (see notifyRoute) can
+// send failures somewhere more urgent than a routine success.
+type notifySeverity string
+
+const (
+	NotifyInfo    notifySeverity = "info"
+	NotifySuccess notifySeverity = "success"
+	NotifyFailure notifySeverity = "failure"
+)
+
+// notifyResult is what CI/Release pass to Notify: the target that ran, its
+// outcome, and whatever context (version, artifact links, vuln counts) is
+// worth surfacing in chat.
+type notifyResult struct {
+	Target   string
+	Severity notifySeverity
+	Message  string
+	Details  map[string]string
+}
+
+// notifyRouteEnv maps a severity to a webhook URL, e.g.
+// NOTIFY_WEBHOOK_FAILURE=https://hooks.slack.com/... and
+// NOTIFY_WEBHOOK_DEFAULT as a fallback for any severity without its own
+// route.
+func notifyRouteEnv(severity notifySeverity) string {
+	switch severity {
+	case NotifyFailure:
+		return "NOTIFY_WEBHOOK_FAILURE"
+	case NotifySuccess:
+		return "NOTIFY_WEBHOOK_SUCCESS"
+	default:
+		return "NOTIFY_WEBHOOK_DEFAULT"
+	}
+}
+
+// Notify groups result-notification targets.
+type Notify mg.Namespace
+
+// Pipeline posts result to whichever webhook notifyRouteEnv resolves for
+// its severity (falling back to NOTIFY_WEBHOOK_DEFAULT), in whatever shape
+// the endpoint expects (Slack incoming webhook, MS Teams connector, or a
+// plain JSON payload for a generic endpoint, chosen by NOTIFY_KIND). Release
+// calls this directly rather than exposing it as a bare mage target,
+// since a result struct isn't something you'd construct from the CLI.
+func (Notify) Pipeline(result notifyResult) error {
+	url := os.Getenv(notifyRouteEnv(result.Severity))
+	if url == "" {
+		url = os.Getenv("NOTIFY_WEBHOOK_DEFAULT")
+	}
+	if url == "" {
+		return nil
+	}
+
+	body, err := notifyPayload(result)
+	if err != nil {
+		return err
+	}
+
+	if isDryRun() {
+		fmt.Printf("[dry-run] POST %s %s\n", url, body)
+		return nil
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("notify webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("notify webhook: unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// notifyPayload renders result according to NOTIFY_KIND (slack, teams, or
+// the default: a plain JSON object), since Slack and Teams each expect a
+// different envelope around the same information.
+func notifyPayload(result notifyResult) ([]byte, error) {
+	switch os.Getenv("NOTIFY_KIND") {
+	case "slack":
+		return json.Marshal(map[string]string{"text": notifyText(result)})
+	case "teams":
+		return json.Marshal(map[string]string{
+			"@type":      "MessageCard",
+			"@context":   "https://schema.org/extensions",
+			"summary":    result.Target,
+			"text":       notifyText(result),
+			"themeColor": notifyThemeColor(result.Severity),
+		})
+	default:
+		return json.Marshal(result)
+	}
+}
+
+func notifyText(result notifyResult) string {
+	text := fmt.Sprintf("[%s] %s: %s", result.Severity, result.Target, result.Message)
+	for k, v := range result.Details {
+		text += fmt.Sprintf("\n%s: %s", k, v)
+	}
+	return text
+}
+
+func notifyThemeColor(severity notifySeverity) string {
+	switch severity {
+	case NotifyFailure:
+		return "dc2626"
+	case NotifySuccess:
+		return "16a34a"
+	default:
+		return "3b82f6"
+	}
+}
@@ -0,0 +1,19 @@
+//go:build mage
+
+package main
+
+import "github.com/magefile/mage/sh"
+
+const openapiSpecPath = "api/openapi.yaml"
+
+// GenerateOpenAPI regenerates api/openapi.yaml from annotated handler
+// comments using swaggo/swag.
+func GenerateOpenAPI() error {
+	return sh.RunV("swag", "init", "--output", "api", "--outputTypes", "yaml")
+}
+
+// ValidateOpenAPI lints api/openapi.yaml with spectral, failing the build on
+// any error-severity rule violation.
+func ValidateOpenAPI() error {
+	return sh.RunV("spectral", "lint", openapiSpecPath, "--fail-severity=error")
+}
@@ -0,0 +1,119 @@
+//go:build mage
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/magefile/mage/sh"
+)
+
+// tapRepoEnv/scoopBucketEnv name the local checkouts of the repos that
+// GenerateHomebrewFormula and GenerateScoopManifest write their generated
+// files into, matching the
+// "tap repo" convention both package managers expect.
+const (
+	tapRepoEnv     = "HOMEBREW_TAP_REPO"
+	scoopBucketEnv = "SCOOP_BUCKET_REPO"
+)
+
+// GenerateHomebrewFormula writes Formula/<binaryName>.rb pointing at the
+// darwin/amd64 and darwin/arm64 release archives and their SHA256 digests,
+// into the repo checked out at HOMEBREW_TAP_REPO.
+func GenerateHomebrewFormula() error {
+	tap := os.Getenv(tapRepoEnv)
+	if tap == "" {
+		return fmt.Errorf("%s must point at a checked-out homebrew tap repo", tapRepoEnv)
+	}
+
+	version, err := sh.Output("git", "describe", "--tags", "--abbrev=0")
+	if err != nil {
+		return err
+	}
+
+	amd64Digest, err := archiveDigest("darwin", "amd64")
+	if err != nil {
+		return err
+	}
+	arm64Digest, err := archiveDigest("darwin", "arm64")
+	if err != nil {
+		return err
+	}
+
+	formula := fmt.Sprintf(`class %s < Formula
+  desc "%s"
+  version "%s"
+
+  on_intel do
+    url "https://github.com/jpoley/rune-forge/releases/download/%s/darwin-amd64.tar.gz"
+    sha256 "%s"
+  end
+
+  on_arm do
+    url "https://github.com/jpoley/rune-forge/releases/download/%s/darwin-arm64.tar.gz"
+    sha256 "%s"
+  end
+
+  def install
+    bin.install "%s"
+  end
+end
+`, homebrewClassName(), binaryName, version,
+		version, amd64Digest,
+		version, arm64Digest,
+		binaryName)
+
+	return os.WriteFile(filepath.Join(tap, "Formula", binaryName+".rb"), []byte(formula), 0o644)
+}
+
+// GenerateScoopManifest writes <binaryName>.json pointing at the
+// windows/amd64 release archive, into the repo checked out at
+// SCOOP_BUCKET_REPO.
+func GenerateScoopManifest() error {
+	bucket := os.Getenv(scoopBucketEnv)
+	if bucket == "" {
+		return fmt.Errorf("%s must point at a checked-out scoop bucket repo", scoopBucketEnv)
+	}
+
+	version, err := sh.Output("git", "describe", "--tags", "--abbrev=0")
+	if err != nil {
+		return err
+	}
+	digest, err := archiveDigest("windows", "amd64")
+	if err != nil {
+		return err
+	}
+
+	manifest := fmt.Sprintf(`{
+  "version": "%s",
+  "url": "https://github.com/jpoley/rune-forge/releases/download/%s/windows-amd64.zip",
+  "hash": "%s",
+  "bin": "%s.exe"
+}
+`, version, version, digest, binaryName)
+
+	return os.WriteFile(filepath.Join(bucket, binaryName+".json"), []byte(manifest), 0o644)
+}
+
+func archiveDigest(goos, arch string) (string, error) {
+	ext := ".tar.gz"
+	if goos == "windows" {
+		ext = ".zip"
+	}
+	path := filepath.Join(outputDir, goos+"-"+arch+ext)
+	return calculateDigest(path, sha256Algo)
+}
+
+// homebrewClassName converts binaryName (e.g. "rune-forge-service") into the
+// CamelCase class name Homebrew formulas require (e.g. "RuneForgeService").
+func homebrewClassName() string {
+	var class strings.Builder
+	for _, word := range strings.FieldsFunc(binaryName, func(r rune) bool { return r == '-' || r == '_' }) {
+		class.WriteString(strings.ToUpper(word[:1]))
+		class.WriteString(word[1:])
+	}
+	return class.String()
+}
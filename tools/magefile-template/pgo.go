@@ -0,0 +1,56 @@
+//go:build mage
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/magefile/mage/sh"
+)
+
+// pgoProfilePath is where CapturePGOProfile writes the CPU profile and
+// where `go build` picks up default.pgo automatically, per the Go toolchain
+// convention of looking in the main package directory.
+func pgoProfilePath(mainPath string) string {
+	return filepath.Join(mainPath, "default.pgo")
+}
+
+// CapturePGOProfile runs the benchmark suite under CPU profiling and copies
+// the result to cmd/service/default.pgo so BuildPGO (and a plain `go build`)
+// picks it up automatically.
+func CapturePGOProfile() error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	profileOut := "cpu.pprof"
+	if err := sh.RunV("go", "test", "-run=^$", "-bench=.", "-cpuprofile="+profileOut, "./..."); err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(profileOut)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(pgoProfilePath(cfg.resolvedMainPath()), data, 0o644)
+}
+
+// BuildPGO builds with -pgo pointed explicitly at the captured profile,
+// failing with a clear error if CapturePGOProfile hasn't been run yet.
+func BuildPGO() error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	profile := pgoProfilePath(cfg.resolvedMainPath())
+	if _, err := os.Stat(profile); os.IsNotExist(err) {
+		return fmt.Errorf("no PGO profile at %s, run `mage capturePGOProfile` first", profile)
+	}
+
+	out := filepath.Join(cfg.resolvedOutputDir(), cfg.resolvedBinaryName())
+	return sh.RunV("go", "build", "-pgo="+profile, "-o", out, cfg.resolvedMainPath())
+}
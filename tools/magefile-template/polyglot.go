@@ -0,0 +1,119 @@
+//go:build mage
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// polyglotConfigFile lists the stack directories to run, in dependency
+// order (e.g. a shared library stack before the services that consume it).
+// When absent, Polyglot falls back to discoverModules's alphabetical order.
+const polyglotConfigFile = ".rune-forge-stacks.yaml"
+
+// polyglotConfig is the shape of polyglotConfigFile.
+type polyglotConfig struct {
+	Stacks []string `yaml:"stacks"`
+}
+
+// polyglotStackResult is one stack's outcome in the aggregated summary.
+type polyglotStackResult struct {
+	Dir     string `json:"dir"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+const polyglotSummaryPath = "bin/polyglot-summary.json"
+
+// Polyglot runs the given mage target (e.g. "ci") in every stack template
+// discovered under modulesRoot, in the order given by polyglotConfigFile if
+// present, stopping at the first failure so a shared-library stack blocks
+// the services built on top of it. Every stack's own bin/run-summary.json,
+// if it wrote one, is folded into bin/polyglot-summary.json at the repo
+// root so CI has one artifact to upload instead of one per stack.
+func Polyglot(target string) error {
+	dirs, err := polyglotStackOrder()
+	if err != nil {
+		return err
+	}
+
+	var results []polyglotStackResult
+	runErr := func() error {
+		for _, dir := range dirs {
+			Step("%s: mage %s", dir, target)
+			cmd := exec.Command("go", "run", "github.com/magefile/mage", target)
+			cmd.Dir = dir
+			cmd.Stdout = os.Stdout
+			cmd.Stderr = os.Stderr
+
+			result := polyglotStackResult{Dir: dir}
+			if err := cmd.Run(); err != nil {
+				result.Success = false
+				result.Error = err.Error()
+				results = append(results, result)
+				return fmt.Errorf("%s: %w", dir, err)
+			}
+			result.Success = true
+			results = append(results, result)
+		}
+		return nil
+	}()
+
+	if flushErr := flushPolyglotSummary(results); flushErr != nil && runErr == nil {
+		return flushErr
+	}
+	return runErr
+}
+
+// polyglotStackOrder returns the stacks to run, from polyglotConfigFile if
+// present, else discoverModules's default ordering.
+func polyglotStackOrder() ([]string, error) {
+	data, err := os.ReadFile(polyglotConfigFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return discoverModules()
+		}
+		return nil, fmt.Errorf("reading %s: %w", polyglotConfigFile, err)
+	}
+
+	var cfg polyglotConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", polyglotConfigFile, err)
+	}
+	return cfg.Stacks, nil
+}
+
+// flushPolyglotSummary writes results, plus each stack's own run-summary.json
+// (if it wrote one) embedded by path, to polyglotSummaryPath.
+func flushPolyglotSummary(results []polyglotStackResult) error {
+	type stackSummary struct {
+		polyglotStackResult
+		RunSummary json.RawMessage `json:"runSummary,omitempty"`
+	}
+
+	summaries := make([]stackSummary, 0, len(results))
+	for _, result := range results {
+		s := stackSummary{polyglotStackResult: result}
+		if data, err := os.ReadFile(filepath.Join(result.Dir, runSummaryPath)); err == nil {
+			s.RunSummary = data
+		}
+		summaries = append(summaries, s)
+	}
+
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(struct {
+		Stacks []stackSummary `json:"stacks"`
+	}{summaries}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(polyglotSummaryPath, data, 0o644)
+}
@@ -0,0 +1,112 @@
+//go:build mage
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// proxyConfig holds the corporate-network settings loaded from
+// cfg.Proxy (see config.go) and applied to every `go` invocation via
+// withProxyEnv, so a contributor behind a proxy only configures it once
+// instead of exporting GOPROXY/GOPRIVATE/SSL_CERT_FILE by hand.
+type proxyConfig struct {
+	// GoProxy sets GOPROXY, e.g. "https://proxy.corp.example,direct".
+	GoProxy string `yaml:"goProxy" json:"goProxy"`
+	// GoPrivate sets GOPRIVATE/GONOSUMCHECK/GONOSUMDB so internal module
+	// paths skip the public checksum database, e.g. "github.com/ourorg/*".
+	GoPrivate string `yaml:"goPrivate" json:"goPrivate"`
+	// CABundle is a PEM file added to SSL_CERT_FILE for tool downloads that
+	// go through a TLS-inspecting corporate proxy.
+	CABundle string `yaml:"caBundle" json:"caBundle"`
+}
+
+// withProxyEnv merges cfg.Proxy's settings into env (env's own keys win),
+// for passing to sh.RunWith / exec.Cmd.Env.
+func (c buildConfig) withProxyEnv(env map[string]string) map[string]string {
+	proxyEnv := map[string]string{}
+	if c.Proxy.GoProxy != "" {
+		proxyEnv["GOPROXY"] = c.Proxy.GoProxy
+	}
+	if c.Proxy.GoPrivate != "" {
+		proxyEnv["GOPRIVATE"] = c.Proxy.GoPrivate
+		proxyEnv["GONOSUMCHECK"] = "1"
+		proxyEnv["GONOSUMDB"] = c.Proxy.GoPrivate
+	}
+	if c.Proxy.CABundle != "" {
+		proxyEnv["SSL_CERT_FILE"] = c.Proxy.CABundle
+	}
+	for k, v := range env {
+		proxyEnv[k] = v
+	}
+	return proxyEnv
+}
+
+// Doctor checks the local environment for everything this magefile assumes
+// is set up correctly, reporting every problem it finds rather than
+// stopping at the first, so a new contributor gets one list to work
+// through instead of a game of whack-a-mole across repeated `mage` runs.
+func Doctor() error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	var problems []string
+	problems = append(problems, doctorCheckProxy(cfg.Proxy)...)
+	problems = append(problems, doctorCheckNetrc(cfg.Proxy)...)
+
+	if len(problems) == 0 {
+		Step("Doctor: environment looks good")
+		return nil
+	}
+	return fmt.Errorf("Doctor found %d problem(s):\n  %s", len(problems), strings.Join(problems, "\n  "))
+}
+
+func doctorCheckProxy(cfg proxyConfig) []string {
+	var problems []string
+
+	if cfg.CABundle != "" {
+		if _, err := os.Stat(cfg.CABundle); err != nil {
+			problems = append(problems, fmt.Sprintf("caBundle %s: %v", cfg.CABundle, err))
+		}
+	}
+
+	if cfg.GoProxy != "" {
+		resp, err := http.Get(firstProxyURL(cfg.GoProxy))
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("GOPROXY %s unreachable: %v", cfg.GoProxy, err))
+		} else {
+			resp.Body.Close()
+		}
+	}
+	return problems
+}
+
+func firstProxyURL(goProxy string) string {
+	first, _, _ := strings.Cut(goProxy, ",")
+	return first
+}
+
+// doctorCheckNetrc warns when GOPRIVATE/GOPROXY are configured for a host
+// but no matching netrc-based auth (see auth.go) has been set up for it.
+func doctorCheckNetrc(cfg proxyConfig) []string {
+	if cfg.GoPrivate == "" && cfg.GoProxy == "" {
+		return nil
+	}
+	netrcPath := os.Getenv("NETRC")
+	if netrcPath == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil
+		}
+		netrcPath = home + "/.netrc"
+	}
+	if _, err := os.Stat(netrcPath); err != nil {
+		return []string{fmt.Sprintf("no netrc at %s; private module hosts will fail authentication (see Auth.Setup)", netrcPath)}
+	}
+	return nil
+}
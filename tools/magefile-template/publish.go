@@ -0,0 +1,67 @@
+//go:build mage
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/magefile/mage/sh"
+)
+
+// publishDestEnv selects where PublishArtifacts uploads bin/, e.g.
+// s3://my-bucket/releases, gs://my-bucket/releases, or an Artifactory repo
+// URL. The scheme picks the uploader.
+const publishDestEnv = "PUBLISH_DEST"
+
+// PublishArtifacts uploads every file under bin/ to PUBLISH_DEST, dispatching
+// to the AWS CLI, gcloud, or a plain curl PUT based on the destination's
+// scheme (s3://, gs://, or https://).
+func PublishArtifacts() error {
+	dest := os.Getenv(publishDestEnv)
+	if dest == "" {
+		return fmt.Errorf("%s must be set, e.g. s3://bucket/releases", publishDestEnv)
+	}
+
+	switch {
+	case strings.HasPrefix(dest, "s3://"):
+		return sh.RunV("aws", "s3", "cp", outputDir, dest, "--recursive")
+	case strings.HasPrefix(dest, "gs://"):
+		return sh.RunV("gcloud", "storage", "cp", "-r", outputDir, dest)
+	case strings.HasPrefix(dest, "https://"):
+		return publishToArtifactory(dest)
+	default:
+		return fmt.Errorf("%s=%q has an unsupported scheme, want s3://, gs://, or https://", publishDestEnv, dest)
+	}
+}
+
+// publishToArtifactory PUTs every file under bin/ to an Artifactory-style
+// generic repo, reading credentials from ARTIFACTORY_USER/ARTIFACTORY_TOKEN.
+func publishToArtifactory(dest string) error {
+	user := os.Getenv("ARTIFACTORY_USER")
+	token := os.Getenv("ARTIFACTORY_TOKEN")
+	if user == "" || token == "" {
+		return fmt.Errorf("ARTIFACTORY_USER and ARTIFACTORY_TOKEN must be set")
+	}
+
+	var assets []string
+	err := filepath.Walk(outputDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		assets = append(assets, path)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, asset := range assets {
+		url := strings.TrimSuffix(dest, "/") + "/" + asset
+		if err := sh.RunV("curl", "-fsSL", "-u", user+":"+token, "-T", asset, url); err != nil {
+			return err
+		}
+	}
+	return nil
+}
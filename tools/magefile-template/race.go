@@ -0,0 +1,97 @@
+//go:build mage
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+// raceReportPath is the structured output TestRace writes, for CI to
+// surface as annotations instead of scrolling through interleaved
+// goroutine dumps in the raw test log.
+const raceReportPath = "bin/race-report.json"
+
+// raceReport is one data race, deduplicated by its first goroutine stack
+// frame — re-running flaky race tests a dozen times produces a dozen near-
+// identical dumps otherwise, most of them different only in goroutine IDs
+// and timing.
+type raceReport struct {
+	Location string `json:"location"` // file:line the race was first detected at
+	Count    int    `json:"count"`    // how many times this location raced across the run
+	Sample   string `json:"sample"`   // one full race block, for when the location alone isn't enough context
+}
+
+const raceWarningHeader = "WARNING: DATA RACE"
+
+var raceLocationPattern = regexp.MustCompile(`\S+\.go:\d+`)
+
+// TestRace runs `go test -race ./...`, and whether or not it reports races,
+// writes raceReportPath summarizing every distinct race location found plus
+// how many times it occurred, so a flaky-race investigation starts from a
+// short deduplicated list instead of the full -race transcript. Returns the
+// same error `go test` would have, after writing the report.
+func TestRace() error {
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		return err
+	}
+
+	cmd := exec.Command("go", "test", "-race", "./...")
+	combined, testErr := cmd.CombinedOutput()
+	fmt.Print(string(combined))
+
+	reports := parseRaceReports(string(combined))
+	if err := writeRaceReport(reports); err != nil {
+		return err
+	}
+	if len(reports) > 0 {
+		fmt.Printf("TestRace: %d distinct race location(s), see %s\n", len(reports), raceReportPath)
+	}
+	return testErr
+}
+
+// parseRaceReports splits go test -race output on its "====" delimiters,
+// keeps the blocks containing a DATA RACE warning, and deduplicates them by
+// the first file:line frame in each block.
+func parseRaceReports(output string) []raceReport {
+	byLocation := map[string]*raceReport{}
+	var order []string
+
+	for _, block := range strings.Split(output, "==================") {
+		if !strings.Contains(block, raceWarningHeader) {
+			continue
+		}
+		loc := strings.TrimSpace(raceLocationPattern.FindString(block))
+		if loc == "" {
+			loc = "unknown location"
+		}
+
+		if existing, ok := byLocation[loc]; ok {
+			existing.Count++
+			continue
+		}
+		byLocation[loc] = &raceReport{Location: loc, Count: 1, Sample: strings.TrimSpace(block)}
+		order = append(order, loc)
+	}
+
+	reports := make([]raceReport, 0, len(order))
+	for _, loc := range order {
+		reports = append(reports, *byLocation[loc])
+	}
+	return reports
+}
+
+func writeRaceReport(reports []raceReport) error {
+	if reports == nil {
+		reports = []raceReport{}
+	}
+	data, err := json.MarshalIndent(reports, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(raceReportPath, data, 0o644)
+}
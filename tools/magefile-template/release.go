@@ -0,0 +1,182 @@
+//go:build mage
+
+package main
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/magefile/mage/sh"
+)
+
+// BuildRelease produces the full cross-compiled artifact set via BuildAll
+// and, when SIGN_RELEASE is set, chains Sign so release artifacts are signed
+// in the same invocation.
+func BuildRelease() error {
+	if err := BuildAll(); err != nil {
+		return err
+	}
+	if err := compressReleaseIfRequested(); err != nil {
+		return err
+	}
+	return signReleaseIfRequested()
+}
+
+// Release is a GoReleaser-style pipeline: it builds every platform, archives
+// each one (.tar.gz on unix, .zip on windows), and writes a CHANGELOG.md entry
+// from `git log` since the previous tag. When SIGN_RELEASE is set, BuildRelease
+// signs the binaries before they are archived; the archives themselves are
+// not signed. It does not publish anything — see the GitHub Release target for that. Its outcome is
+// reported via Notify.Pipeline (see notify.go) so a release failure reaches
+// chat without someone having to go watch the CI run.
+func Release() error {
+	err := releaseSteps()
+	notifyReleaseResult(err)
+	return err
+}
+
+func releaseSteps() error {
+	if err := BuildRelease(); err != nil {
+		return err
+	}
+	if err := archiveReleaseArtifacts(); err != nil {
+		return err
+	}
+	return writeChangelog()
+}
+
+func notifyReleaseResult(err error) {
+	result := notifyResult{Target: "release", Severity: NotifySuccess, Message: "release completed"}
+	if err != nil {
+		result.Severity = NotifyFailure
+		result.Message = err.Error()
+	}
+	if notifyErr := (Notify{}).Pipeline(result); notifyErr != nil {
+		Warnf("notify: %v", notifyErr)
+	}
+}
+
+// archiveReleaseArtifacts packages each bin/<os>-<arch>/ directory produced
+// by BuildAll into a single compressed archive alongside it.
+func archiveReleaseArtifacts() error {
+	for _, p := range crossCompileTargets {
+		dir := filepath.Join(outputDir, p.os+"-"+p.arch)
+		if _, err := os.Stat(dir); os.IsNotExist(err) {
+			continue
+		}
+
+		var archivePath string
+		var err error
+		if p.os == "windows" {
+			archivePath = dir + ".zip"
+			err = zipDir(dir, archivePath)
+		} else {
+			archivePath = dir + ".tar.gz"
+			err = tarGzDir(dir, archivePath)
+		}
+		if err != nil {
+			return fmt.Errorf("archiving %s: %w", dir, err)
+		}
+	}
+	return nil
+}
+
+func tarGzDir(dir, archivePath string) error {
+	f, err := os.Create(archivePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	defer gz.Close()
+	tw := tar.NewWriter(gz)
+	defer tw.Close()
+
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		hdr, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		hdr.Name = rel
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		src, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+		_, err = io.Copy(tw, src)
+		return err
+	})
+}
+
+func zipDir(dir, archivePath string) error {
+	f, err := os.Create(archivePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	zw := zip.NewWriter(f)
+	defer zw.Close()
+
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		w, err := zw.Create(rel)
+		if err != nil {
+			return err
+		}
+		src, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+		_, err = io.Copy(w, src)
+		return err
+	})
+}
+
+// writeChangelog writes CHANGELOG.md from the commit log since the previous
+// tag, grouped under the current HEAD's tag (or "Unreleased").
+func writeChangelog() error {
+	prevTag, _ := sh.Output("git", "describe", "--tags", "--abbrev=0", "HEAD^")
+	rangeSpec := "HEAD"
+	if prevTag != "" {
+		rangeSpec = prevTag + "..HEAD"
+	}
+
+	log, err := sh.Output("git", "log", "--pretty=format:- %s (%h)", rangeSpec)
+	if err != nil {
+		return err
+	}
+
+	tag, _ := sh.Output("git", "describe", "--tags", "--exact-match", "HEAD")
+	heading := "Unreleased"
+	if tag != "" {
+		heading = tag
+	}
+
+	entry := fmt.Sprintf("## %s\n\n%s\n\n", heading, log)
+	existing, _ := os.ReadFile("CHANGELOG.md")
+	return os.WriteFile("CHANGELOG.md", append([]byte(entry), existing...), 0o644)
+}
@@ -0,0 +1,79 @@
+//go:build mage
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+)
+
+// Run builds the service and starts it with the dev profile's environment
+// (see env.go), forwarding SIGINT/SIGTERM to the child so Ctrl-C shuts it
+// down cleanly, and pretty-printing its stdout if it's emitting structured
+// (one-JSON-object-per-line) logs. This replaces the go-run-./cmd/api
+// one-liner every contributor was writing themselves.
+func Run() error {
+	if err := Build(); err != nil {
+		return err
+	}
+
+	env, err := withEnvFiles(nil)
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.Command(filepath.Join(outputDir, binaryName))
+	cmd.Env = os.Environ()
+	for k, v := range env {
+		cmd.Env = append(cmd.Env, k+"="+v)
+	}
+	cmd.Stderr = os.Stderr
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		sig := <-sigCh
+		_ = cmd.Process.Signal(sig)
+	}()
+
+	prettyPrintLogs(stdout, os.Stdout)
+	return cmd.Wait()
+}
+
+// prettyPrintLogs copies src to dst, reformatting any line that parses as a
+// JSON object into an indented form; lines that aren't structured logs pass
+// through unchanged.
+func prettyPrintLogs(src io.Reader, dst io.Writer) {
+	scanner := bufio.NewScanner(src)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+
+		var entry map[string]any
+		if err := json.Unmarshal(line, &entry); err != nil {
+			fmt.Fprintln(dst, string(line))
+			continue
+		}
+		pretty, err := json.MarshalIndent(entry, "", "  ")
+		if err != nil {
+			fmt.Fprintln(dst, string(line))
+			continue
+		}
+		fmt.Fprintln(dst, string(pretty))
+	}
+}
@@ -0,0 +1,95 @@
+//go:build mage
+
+package main
+
+import (
+	"fmt"
+	"sync"
+)
+
+// targetDeps declares the dependency graph used by RunGraph: a target
+// listed here only starts once every target in its slice has finished
+// successfully. Targets with no entry are assumed to have no dependencies.
+var targetDeps = map[string][]string{
+	"coverage":            {"test"},
+	"checkGenerate":       {"generate"},
+	"buildRelease":        {"buildAll"},
+	"release":             {"buildRelease"},
+	"generateGithubActions": nil,
+}
+
+// RunGraph runs every target in names, respecting targetDeps: targets whose
+// dependencies are already satisfied run concurrently, and the whole graph
+// fails fast on the first error. run is the function that actually executes
+// a single named target (normally a thin switch to the real mage targets).
+func RunGraph(names []string, run func(name string) error) error {
+	var (
+		mu       sync.Mutex
+		done     = map[string]bool{}
+		failed   error
+		wg       sync.WaitGroup
+		inflight = map[string]bool{}
+	)
+
+	var schedule func()
+	var cond = sync.NewCond(&mu)
+
+	schedule = func() {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, name := range names {
+			if done[name] || inflight[name] || failed != nil {
+				continue
+			}
+			if !depsSatisfied(name, done) {
+				continue
+			}
+			inflight[name] = true
+			wg.Add(1)
+			go func(name string) {
+				defer wg.Done()
+				err := run(name)
+				mu.Lock()
+				if err != nil && failed == nil {
+					failed = fmt.Errorf("%s: %w", name, err)
+				}
+				done[name] = true
+				delete(inflight, name)
+				mu.Unlock()
+				cond.Broadcast()
+			}(name)
+		}
+	}
+
+	mu.Lock()
+	for !allDone(names, done) && failed == nil {
+		mu.Unlock()
+		schedule()
+		mu.Lock()
+		if !allDone(names, done) && failed == nil {
+			cond.Wait()
+		}
+	}
+	mu.Unlock()
+
+	wg.Wait()
+	return failed
+}
+
+func depsSatisfied(name string, done map[string]bool) bool {
+	for _, dep := range targetDeps[name] {
+		if !done[dep] {
+			return false
+		}
+	}
+	return true
+}
+
+func allDone(names []string, done map[string]bool) bool {
+	for _, n := range names {
+		if !done[n] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,165 @@
+//go:build mage
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/magefile/mage/sh"
+)
+
+// sarifOutEnv, when set, asks every security-scan target to additionally
+// write a SARIF report to <bin>/<scanner>.sarif so results can be uploaded
+// as GitHub code-scanning alerts. Scanners that can't natively emit SARIF
+// (grype) still run but write no SARIF report; this does not fail the build.
+const sarifOutEnv = "SARIF_OUT"
+
+// failOnSeverityEnv sets the minimum trivy/grype severity that fails the
+// build, e.g. FAIL_ON_SEVERITY=CRITICAL to let HIGH findings pass. trivy
+// reports only findings at the listed severities. Defaults to trivy's own default (UNKNOWN,LOW,MEDIUM,HIGH,CRITICAL).
+const failOnSeverityEnv = "FAIL_ON_SEVERITY"
+
+// ScanFS runs a filesystem vulnerability scan over the module with trivy.
+func ScanFS() error {
+	args := []string{"fs", "--exit-code", "1"}
+	if sev := os.Getenv(failOnSeverityEnv); sev != "" {
+		args = append(args, "--severity", sev)
+	}
+	if sarifEnabled() {
+		args = append(args, "--format", "sarif", "--output", sarifPath("trivy-fs"))
+	}
+	return sh.RunV("trivy", append(args, ".")...)
+}
+
+// ScanImage runs a container vulnerability scan against the image named by
+// the IMAGE env var (see container.go) using trivy, falling back to grype if
+// SCANNER=grype is set. grype has no SARIF writer, so SARIF_OUT is a no-op
+// for it.
+func ScanImage() error {
+	image, err := requireImageRef()
+	if err != nil {
+		return err
+	}
+	if os.Getenv("SCANNER") == "grype" {
+		return sh.RunV("grype", image, "--fail-on", grypeSeverity())
+	}
+
+	args := []string{"image", "--exit-code", "1"}
+	if sev := os.Getenv(failOnSeverityEnv); sev != "" {
+		args = append(args, "--severity", sev)
+	}
+	if sarifEnabled() {
+		args = append(args, "--format", "sarif", "--output", sarifPath("trivy-image"))
+	}
+	return sh.RunV("trivy", append(args, image)...)
+}
+
+// grypeSeverity maps FAIL_ON_SEVERITY to grype's lowercase --fail-on values,
+// defaulting to "medium" to match the old hard-coded behavior.
+func grypeSeverity() string {
+	sev := strings.ToLower(os.Getenv(failOnSeverityEnv))
+	if sev == "" {
+		return "medium"
+	}
+	return sev
+}
+
+func sarifEnabled() bool {
+	return os.Getenv(sarifOutEnv) != ""
+}
+
+func sarifPath(scanner string) string {
+	return filepath.Join(outputDir, scanner+".sarif")
+}
+
+// osvScannerReport is the subset of osv-scanner's JSON output ScanVulnerabilities
+// needs to apply waivers.
+type osvScannerReport struct {
+	Results []struct {
+		Packages []struct {
+			Vulnerabilities []struct {
+				ID string `json:"id"`
+			} `json:"vulnerabilities"`
+		} `json:"packages"`
+	} `json:"results"`
+}
+
+// ScanVulnerabilities runs govulncheck (Go-stdlib-and-module-aware) and
+// osv-scanner (broader OSV database coverage, including transitive
+// non-Go-specific advisories), then applies security-waivers.json (see
+// waivers.go) before failing the build. Any expired waiver is reported even
+// if the underlying finding would otherwise be silent.
+func ScanVulnerabilities() error {
+	if err := sh.RunV("govulncheck", "./..."); err != nil {
+		return fmt.Errorf("govulncheck: %w", err)
+	}
+
+	reportPath := outputDir + "/osv-report.json"
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		return err
+	}
+	args := []string{"scan", "source", "-r", ".", "--format", "json", "--output", reportPath}
+	// osv-scanner exits non-zero when it finds anything; we still want the
+	// report on disk to evaluate against waivers below.
+	_ = sh.RunV("osv-scanner", args...)
+	if sarifEnabled() {
+		sarifArgs := []string{"scan", "source", "-r", ".", "--format", "sarif", "--output", sarifPath("osv-scanner")}
+		_ = sh.RunV("osv-scanner", sarifArgs...)
+	}
+
+	findings, err := osvFindingIDs(reportPath)
+	if err != nil {
+		return err
+	}
+
+	remaining, expired, err := applyWaivers(findings)
+	if err != nil {
+		return err
+	}
+	if len(expired) > 0 {
+		return fmt.Errorf("waiver(s) expired for: %s, re-review and update %s", strings.Join(expired, ", "), waiversPath)
+	}
+	if len(remaining) > 0 {
+		return fmt.Errorf("unwaived vulnerabilities found: %s", strings.Join(remaining, ", "))
+	}
+	return nil
+}
+
+func osvFindingIDs(reportPath string) ([]string, error) {
+	data, err := os.ReadFile(reportPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var report osvScannerReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", reportPath, err)
+	}
+
+	var ids []string
+	for _, result := range report.Results {
+		for _, pkg := range result.Packages {
+			for _, vuln := range pkg.Vulnerabilities {
+				ids = append(ids, vuln.ID)
+			}
+		}
+	}
+	return ids, nil
+}
+
+// ScanSecrets runs gitleaks over the full git history, catching committed
+// credentials a point-in-time filesystem scan would miss.
+func ScanSecrets() error {
+	args := []string{"detect", "--source", ".", "--no-banner"}
+	if sarifEnabled() {
+		args = append(args, "--report-format", "sarif", "--report-path", sarifPath("gitleaks"))
+	}
+	return sh.RunV("gitleaks", args...)
+}
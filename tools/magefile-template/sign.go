@@ -0,0 +1,69 @@
+//go:build mage
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// signAutoEnv opts BuildRelease into chaining Sign automatically when set to
+// a truthy value, e.g. SIGN_RELEASE=1 mage buildRelease.
+const signAutoEnv = "SIGN_RELEASE"
+
+// Sign signs every artifact under bin/ with cosign, producing a .sig and
+// .pem bundle next to each one. Set COSIGN_KEY to a key file/KMS URI for
+// key-based signing; otherwise it falls back to keyless signing via OIDC,
+// which is only viable in CI providers cosign recognizes (e.g. GitHub
+// Actions).
+func Sign() error {
+	artifacts, err := signableArtifacts()
+	if err != nil {
+		return err
+	}
+	if len(artifacts) == 0 {
+		return nil
+	}
+
+	key := os.Getenv("COSIGN_KEY")
+	for _, artifact := range artifacts {
+		args := []string{"sign-blob", "--yes", "--output-signature", artifact + ".sig", "--output-certificate", artifact + ".pem"}
+		if key != "" {
+			args = append(args, "--key", key)
+		}
+		args = append(args, artifact)
+		if err := runv("cosign", args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// signableArtifacts returns every regular file under bin/ that isn't already
+// a signature, certificate, or checksum manifest.
+func signableArtifacts() ([]string, error) {
+	var artifacts []string
+	err := filepath.Walk(outputDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		if strings.HasSuffix(path, ".sig") || strings.HasSuffix(path, ".pem") ||
+			strings.HasSuffix(path, ".sha256") || filepath.Base(path) == "checksums.txt" {
+			return nil
+		}
+		artifacts = append(artifacts, path)
+		return nil
+	})
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return artifacts, err
+}
+
+func signReleaseIfRequested() error {
+	if v := strings.ToLower(os.Getenv(signAutoEnv)); v == "1" || v == "true" {
+		return Sign()
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+//go:build mage
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// smokeCheck is one endpoint Smoke hits after boot.
+type smokeCheck struct {
+	Name           string `yaml:"name" json:"name"`
+	URL            string `yaml:"url" json:"url"`
+	ExpectedStatus int    `yaml:"expectedStatus" json:"expectedStatus"`
+	ExpectedBody   string `yaml:"expectedBody" json:"expectedBody"`
+}
+
+// Smoke builds the binary, boots it, waits for it to become healthy (reusing
+// e2e.go's waitForHealthy), then hits every check in cfg.SmokeChecks and
+// reports pass/fail with the captured response body for each, replacing the
+// bash script this was previously scripted as.
+func Smoke() error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if len(cfg.SmokeChecks) == 0 {
+		Step("Smoke: no smokeChecks configured, nothing to do")
+		return nil
+	}
+
+	if err := Build(); err != nil {
+		return err
+	}
+
+	bin := filepath.Join(outputDir, binaryName)
+	cmd := exec.Command(bin)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("starting %s: %w", bin, err)
+	}
+	defer func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	}()
+
+	if err := waitForHealthy(e2eHealthURL, 30*time.Second); err != nil {
+		return err
+	}
+
+	var failures []string
+	for _, check := range cfg.SmokeChecks {
+		body, err := runSmokeCheck(check)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", check.Name, err))
+		} else {
+			Step("ok: %s (body: %s)", check.Name, body)
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("Smoke: %d check(s) failed:\n  %s", len(failures), strings.Join(failures, "\n  "))
+	}
+	return nil
+}
+
+func runSmokeCheck(check smokeCheck) ([]byte, error) {
+	resp, err := http.Get(check.URL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if check.ExpectedStatus != 0 && resp.StatusCode != check.ExpectedStatus {
+		return body, fmt.Errorf("status %d, want %d (body: %s)", resp.StatusCode, check.ExpectedStatus, body)
+	}
+	if check.ExpectedBody != "" && !strings.Contains(string(body), check.ExpectedBody) {
+		return body, fmt.Errorf("body %q does not contain %q", body, check.ExpectedBody)
+	}
+	return body, nil
+}
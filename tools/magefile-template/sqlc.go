@@ -0,0 +1,72 @@
+//go:build mage
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/magefile/mage/sh"
+)
+
+// sqlcConfigFile is sqlc's own config, not this template's — see
+// https://docs.sqlc.dev/en/latest/reference/config.html.
+const sqlcConfigFile = "sqlc.yaml"
+
+// schemaSnapshotPath is the committed "source of truth" schema dump that
+// SQLSchemaDrift compares a freshly-migrated database against.
+const schemaSnapshotPath = "schema.sql"
+
+// GenerateSQL runs sqlc generate with the sqlcConfigFile at the module root
+// (and does nothing when that file is absent), registered in generators (see generate.go) so it's covered by both
+// `mage generate` and CheckGenerate's drift detection for the generated Go
+// code. SQLSchemaDrift (below) separately checks the schema snapshot itself.
+func GenerateSQL() error {
+	if _, err := os.Stat(sqlcConfigFile); os.IsNotExist(err) {
+		return nil
+	}
+	return sh.RunV("sqlc", "generate")
+}
+
+func init() {
+	generators = append(generators, struct {
+		name string
+		run  func() error
+	}{"sql", GenerateSQL})
+}
+
+// sqlDriftDatabaseURLEnv points at a scratch Postgres instance
+// SQLSchemaDrift can freely apply migrations to and drop, e.g. a throwaway
+// CI service container.
+const sqlDriftDatabaseURLEnv = "SQL_DRIFT_DATABASE_URL"
+
+// SQLSchemaDrift applies every migration in migrationsDir to a scratch
+// database, dumps its resulting schema, and fails if it doesn't match the
+// committed schemaSnapshotPath — catching a migration that was edited after
+// being merged, or one that doesn't actually produce the schema the
+// generated sqlc code assumes.
+func SQLSchemaDrift() error {
+	dbURL := os.Getenv(sqlDriftDatabaseURLEnv)
+	if dbURL == "" {
+		return fmt.Errorf("%s must be set to a scratch database, e.g. postgres://localhost:5433/driftcheck", sqlDriftDatabaseURLEnv)
+	}
+
+	if err := sh.RunV("migrate", "-path", migrationsDir, "-database", dbURL, "up"); err != nil {
+		return fmt.Errorf("applying migrations: %w", err)
+	}
+
+	dumped, err := sh.Output("pg_dump", "--schema-only", "--no-owner", "--no-privileges", dbURL)
+	if err != nil {
+		return fmt.Errorf("dumping schema: %w", err)
+	}
+
+	committed, err := os.ReadFile(schemaSnapshotPath)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", schemaSnapshotPath, err)
+	}
+
+	if dumped != string(committed) {
+		return fmt.Errorf("SQLSchemaDrift: %s is stale; regenerate it from a freshly migrated database and commit the result", schemaSnapshotPath)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+//go:build mage
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+)
+
+const runSummaryPath = "bin/run-summary.json"
+
+// targetResult is one entry in the machine-readable run summary written by
+// WithSummary.
+type targetResult struct {
+	Target    string    `json:"target"`
+	StartedAt time.Time `json:"startedAt"`
+	Duration  string    `json:"duration"`
+	Success   bool      `json:"success"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// runSummary accumulates results across every WithSummary call in a single
+// mage invocation.
+var runSummary struct {
+	Results []targetResult `json:"results"`
+}
+
+// WithSummary runs fn under the name target, recording its outcome and
+// timing into runSummary, then always flushes bin/run-summary.json -
+// even on failure - so CI can upload it as a build artifact. It also feeds
+// the same timing into recordMetric (see metrics.go) so a single call site
+// produces both the pass/fail summary and the performance report, and opens
+// an OTel span for the target (see tracing.go) when OTEL_EXPORTER_OTLP_ENDPOINT
+// is set. fn doesn't take a context, so child spans for sub-commands within
+// a target are opt-in via traceCommand rather than automatic.
+func WithSummary(target string, fn func() error) error {
+	_, endSpan := startTargetSpan(target)
+
+	start := time.Now()
+	err := fn()
+	duration := time.Since(start)
+
+	endSpan(err)
+
+	result := targetResult{
+		Target:    target,
+		StartedAt: start,
+		Duration:  duration.String(),
+		Success:   err == nil,
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	runSummary.Results = append(runSummary.Results, result)
+	recordMetric(target, start, duration)
+
+	if writeErr := flushRunSummary(); writeErr != nil && err == nil {
+		return writeErr
+	}
+	if writeErr := flushBuildMetrics(); writeErr != nil && err == nil {
+		return writeErr
+	}
+	return err
+}
+
+func flushRunSummary() error {
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(runSummary, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(runSummaryPath, data, 0o644)
+}
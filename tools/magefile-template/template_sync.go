@@ -0,0 +1,41 @@
+//go:build mage
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// templateSourceFile holds the git remote this module was scaffolded from,
+// recorded by the scaffolding CLI (see scaffold.go) in .rune-forge-template.
+const templateSourceFile = ".rune-forge-template"
+
+// SyncTemplate pulls the latest commit from the template this project was
+// scaffolded from and three-way merges it into the working tree, so
+// template improvements (a new security scanner, a CI fix) can flow into
+// projects that already diverged from the original scaffold.
+func SyncTemplate() error {
+	remote, err := os.ReadFile(templateSourceFile)
+	if err != nil {
+		return fmt.Errorf("no %s found; this project wasn't scaffolded with `rune-forge init`: %w", templateSourceFile, err)
+	}
+
+	const templateRef = "rune-forge-template"
+	if err := run("git", "fetch", string(remote), "main:refs/remotes/"+templateRef); err != nil {
+		return err
+	}
+
+	// git merge performs a 3-way merge against the common ancestor; conflicts
+	// are left in the working tree for the caller to resolve, same as any
+	// other merge.
+	return run("git", "merge", "--allow-unrelated-histories", "refs/remotes/"+templateRef)
+}
+
+func run(name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
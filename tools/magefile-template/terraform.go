@@ -0,0 +1,40 @@
+//go:build mage
+
+package main
+
+import (
+	"path/filepath"
+
+	"github.com/magefile/mage/sh"
+)
+
+const terraformDir = "deploy/terraform"
+
+// TerraformPlan runs `terraform init` then `plan`, writing the plan to
+// bin/terraform.plan for TerraformApply to consume, so what gets applied is
+// exactly what was reviewed.
+func TerraformPlan() error {
+	if err := sh.RunV("terraform", "-chdir="+terraformDir, "init", "-input=false"); err != nil {
+		return err
+	}
+	planPath, err := terraformPlanPath()
+	if err != nil {
+		return err
+	}
+	return sh.RunV("terraform", "-chdir="+terraformDir, "plan", "-input=false", "-out="+planPath)
+}
+
+// TerraformApply applies the plan produced by TerraformPlan. It refuses to
+// run if that plan file doesn't exist, rather than silently falling back to
+// an unreviewed `terraform apply`.
+func TerraformApply() error {
+	planPath, err := terraformPlanPath()
+	if err != nil {
+		return err
+	}
+	return sh.RunV("terraform", "-chdir="+terraformDir, "apply", "-input=false", planPath)
+}
+
+func terraformPlanPath() (string, error) {
+	return filepath.Abs(filepath.Join(outputDir, "terraform.plan"))
+}
@@ -0,0 +1,180 @@
+//go:build mage
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/magefile/mage/sh"
+)
+
+const coverageProfile = "coverage.out"
+
+// Test runs `go test ./...` with coverage recorded to coverage.out. Set
+// MAGE_REMOTE_CACHE to share build/test cache with other runs (see
+// cache.go), and RUNE_ENV_PROFILE to pick which .env.<profile> file is
+// layered into the test process's environment (see env.go).
+func Test() error {
+	env, err := withEnvFiles(withRemoteCache(nil))
+	if err != nil {
+		return err
+	}
+	return runWith(env, "go", "test", "-coverprofile="+coverageProfile, "./...")
+}
+
+// TestShort runs `go test -short ./...` without coverage instrumentation,
+// for fast feedback loops like the pre-push git hook (see hooks.go) where a
+// full Test run would be too slow to wait on.
+func TestShort() error {
+	return runWith(withRemoteCache(nil), "go", "test", "-short", "./...")
+}
+
+// shardEnv / totalShardsEnv select a 0-indexed slice of packages for
+// TestShard, so a CI matrix can split the suite across N runners.
+const (
+	shardEnv       = "TEST_SHARD_INDEX"
+	totalShardsEnv = "TEST_SHARD_TOTAL"
+)
+
+// TestShard runs Test against only the subset of packages assigned to this
+// shard, determined by TEST_SHARD_INDEX/TEST_SHARD_TOTAL (e.g. index 0 of 4).
+// Packages are distributed round-robin by sorted import path so the split is
+// stable across runs.
+func TestShard() error {
+	index, total, err := shardBounds()
+	if err != nil {
+		return err
+	}
+
+	all, err := sh.Output("go", "list", "./...")
+	if err != nil {
+		return err
+	}
+
+	var shardPkgs []string
+	for i, pkg := range strings.Split(strings.TrimSpace(all), "\n") {
+		if i%total == index {
+			shardPkgs = append(shardPkgs, pkg)
+		}
+	}
+	if len(shardPkgs) == 0 {
+		fmt.Printf("shard %d/%d has no packages assigned\n", index, total)
+		return nil
+	}
+
+	args := append([]string{"test", "-coverprofile=" + coverageProfile}, shardPkgs...)
+	return sh.RunWith(withRemoteCache(nil), "go", args...)
+}
+
+func shardBounds() (index, total int, err error) {
+	total, err = strconv.Atoi(os.Getenv(totalShardsEnv))
+	if err != nil || total < 1 {
+		return 0, 0, fmt.Errorf("%s must be a positive integer", totalShardsEnv)
+	}
+	index, err = strconv.Atoi(os.Getenv(shardEnv))
+	if err != nil || index < 0 || index >= total {
+		return 0, 0, fmt.Errorf("%s must be an integer in [0,%d)", shardEnv, total)
+	}
+	return index, total, nil
+}
+
+// TestJUnit runs the same suite as Test but pipes `go test -json` output
+// through gotestsum to also produce bin/junit.xml, for CI systems (GitLab,
+// Jenkins, Azure) that render JUnit reports natively.
+func TestJUnit() error {
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		return err
+	}
+	return sh.RunWith(withRemoteCache(nil), "gotestsum",
+		"--junitfile", filepath.Join(outputDir, "junit.xml"),
+		"--", "-coverprofile="+coverageProfile, "./...")
+}
+
+// Coverage runs Test and then enforces coverageThreshold from the repo
+// config (see config.go), plus any per-package overrides in
+// packageCoverageThresholds, failing the build with the offending packages
+// listed if any fall short.
+func Coverage() error {
+	if err := Test(); err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	coverage, err := parseCoverageByPackage(coverageProfile)
+	if err != nil {
+		return err
+	}
+
+	var failures []string
+	for pkg, pct := range coverage {
+		threshold := cfg.CoverageThreshold
+		if override, ok := cfg.PackageCoverageThresholds[pkg]; ok {
+			threshold = override
+		}
+		if threshold > 0 && pct < threshold {
+			failures = append(failures, fmt.Sprintf("%s: %.1f%% < %.1f%%", pkg, pct, threshold))
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("coverage threshold not met:\n  %s", strings.Join(failures, "\n  "))
+	}
+	return nil
+}
+
+// parseCoverageByPackage reduces a go test -coverprofile file to a per-
+// package percentage using `go tool cover -func`: the mean of the package's
+// per-function percentages, not weighted by statement count.
+func parseCoverageByPackage(profile string) (map[string]float64, error) {
+	out, err := sh.Output("go", "tool", "cover", "-func="+profile)
+	if err != nil {
+		return nil, err
+	}
+
+	totals := map[string][2]float64{} // pkg -> [sum of function percentages, function count]
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		line := scanner.Text()
+		fields := strings.Fields(line)
+		if len(fields) < 3 || !strings.HasSuffix(fields[len(fields)-1], "%") {
+			continue
+		}
+		pkg := packageOf(fields[0])
+		pctStr := strings.TrimSuffix(fields[len(fields)-1], "%")
+		pct, err := strconv.ParseFloat(pctStr, 64)
+		if err != nil {
+			continue
+		}
+		entry := totals[pkg]
+		entry[0] += pct
+		entry[1]++
+		totals[pkg] = entry
+	}
+
+	result := map[string]float64{}
+	for pkg, sums := range totals {
+		if sums[1] == 0 {
+			continue
+		}
+		result[pkg] = sums[0] / sums[1]
+	}
+	return result, nil
+}
+
+func packageOf(fileAndLine string) string {
+	path := strings.SplitN(fileAndLine, ":", 2)[0]
+	if i := strings.LastIndex(path, "/"); i != -1 {
+		return path[:i]
+	}
+	return "."
+}
+
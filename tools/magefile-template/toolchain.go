@@ -0,0 +1,47 @@
+//go:build mage
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/magefile/mage/sh"
+)
+
+// EnsureToolchain verifies the `go` on PATH matches the `go` directive in
+// go.mod exactly (relying on Go 1.21+'s automatic toolchain download would
+// still hit the network, which offline mode forbids), failing with an
+// actionable message rather than silently building with the wrong compiler.
+func EnsureToolchain() error {
+	want, err := wantGoVersion()
+	if err != nil {
+		return err
+	}
+
+	got, err := sh.Output("go", "env", "GOVERSION")
+	if err != nil {
+		return err
+	}
+	got = strings.TrimPrefix(got, "go")
+
+	if got != want {
+		return fmt.Errorf("go.mod requires go %s, but `go` on PATH is %s; install it with `go install golang.org/dl/go%s@latest && go%s download`", want, got, want, want)
+	}
+	return nil
+}
+
+func wantGoVersion() (string, error) {
+	data, err := os.ReadFile("go.mod")
+	if err != nil {
+		return "", err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "go ") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "go")), nil
+		}
+	}
+	return "", fmt.Errorf("no `go` directive found in go.mod")
+}
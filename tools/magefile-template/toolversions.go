@@ -0,0 +1,70 @@
+//go:build mage
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// toolManifestPath pins the exact version and checksum of every external
+// CLI this magefile shells out to (trivy, cosign, syft, ...), so two
+// machines running `mage` never silently drift onto different tool builds.
+const toolManifestPath = "tools.yaml"
+
+type toolSpec struct {
+	Version string `yaml:"version"`
+	SHA256  string `yaml:"sha256"`
+	URL     string `yaml:"url"`
+}
+
+// loadToolManifest reads tools.yaml into a map of tool name -> toolSpec.
+func loadToolManifest() (map[string]toolSpec, error) {
+	data, err := os.ReadFile(toolManifestPath)
+	if os.IsNotExist(err) {
+		return map[string]toolSpec{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest map[string]toolSpec
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", toolManifestPath, err)
+	}
+	return manifest, nil
+}
+
+// VerifyToolVersions compares, for every tool in tools.yaml, the digest
+// recorded in tools/vendor-bin/<name>.sha256 with the sha256 pinned in the
+// manifest; tools without that file are skipped. It does not check the
+// version of the tool on PATH, and nothing in this magefile (VendorTools
+// included) writes the .sha256 files.
+func VerifyToolVersions() error {
+	manifest, err := loadToolManifest()
+	if err != nil {
+		return err
+	}
+
+	var mismatches []string
+	for name, spec := range manifest {
+		digestFile := "tools/vendor-bin/" + name + ".sha256"
+		recorded, err := os.ReadFile(digestFile)
+		if os.IsNotExist(err) {
+			continue // not vendored locally; nothing to verify
+		}
+		if err != nil {
+			return err
+		}
+		if string(recorded) != spec.SHA256 {
+			mismatches = append(mismatches, fmt.Sprintf("%s: pinned %s, vendored %s", name, spec.SHA256, recorded))
+		}
+	}
+
+	if len(mismatches) > 0 {
+		return fmt.Errorf("tool checksum mismatch:\n  %v", mismatches)
+	}
+	return nil
+}
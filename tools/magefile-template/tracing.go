@@ -0,0 +1,106 @@
+//go:build mage
+
+package main
+
+import (
+	"context"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// otlpEndpointEnv, when set (e.g. to "otel-collector.internal:4318"), turns
+// on span export for the run; every target run through WithSummary becomes a
+// span, with child spans for the external commands it runs via traceCommand.
+// Targets not wrapped in WithSummary produce no span. Unset, tracer() falls
+// back to the global no-op tracer, so instrumentation has zero cost when
+// nobody asked for it.
+const otlpEndpointEnv = "OTEL_EXPORTER_OTLP_ENDPOINT"
+
+var tracerProvider *sdktrace.TracerProvider
+
+// initTracer configures the OTLP/HTTP exporter from OTEL_EXPORTER_OTLP_ENDPOINT
+// and installs it as the global tracer provider. Call once per mage
+// invocation (from WithSummary's first use); safe to call when the env var
+// is unset, in which case it's a no-op.
+func initTracer() error {
+	endpoint := os.Getenv(otlpEndpointEnv)
+	if endpoint == "" || tracerProvider != nil {
+		return nil
+	}
+
+	exporter, err := otlptracehttp.New(context.Background(), otlptracehttp.WithEndpoint(endpoint))
+	if err != nil {
+		return err
+	}
+
+	res, err := resource.New(context.Background(),
+		resource.WithAttributes(semconv.ServiceName(binaryName+"-build")))
+	if err != nil {
+		return err
+	}
+
+	tracerProvider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(tracerProvider)
+	return nil
+}
+
+// shutdownTracer flushes and closes the exporter; call once at the end of a
+// mage invocation. No-op when tracing was never initialized.
+func shutdownTracer() error {
+	if tracerProvider == nil {
+		return nil
+	}
+	return tracerProvider.Shutdown(context.Background())
+}
+
+func tracer() trace.Tracer {
+	return otel.Tracer("github.com/jpoley/rune-forge/tools/magefile-template")
+}
+
+// startTargetSpan starts a span for a mage target, returning the context
+// child spans (see traceCommand) should use, and a func to end the span
+// recording err.
+func startTargetSpan(target string) (context.Context, func(err error)) {
+	if err := initTracer(); err != nil {
+		Warnf("otel: %v", err)
+	}
+
+	ctx, span := tracer().Start(context.Background(), "mage:"+target)
+	return ctx, func(err error) {
+		if err != nil {
+			span.RecordError(err)
+		}
+		span.End()
+
+		// mage runs each target as its own short-lived process, so there's
+		// no natural place to defer shutdownTracer(); force-flush here
+		// instead so the span reaches the collector before the process
+		// exits.
+		if tracerProvider != nil {
+			_ = tracerProvider.ForceFlush(context.Background())
+		}
+	}
+}
+
+// traceCommand wraps an external command invocation (go build, docker,
+// cosign, ...) in a child span of ctx, so an OTel backend shows time spent
+// per sub-command within a target, not just the target total.
+func traceCommand(ctx context.Context, name string, fn func() error) error {
+	_, span := tracer().Start(ctx, "cmd:"+name)
+	defer span.End()
+
+	err := fn()
+	if err != nil {
+		span.RecordError(err)
+	}
+	return err
+}
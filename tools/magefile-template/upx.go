@@ -0,0 +1,46 @@
+//go:build mage
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/magefile/mage/sh"
+)
+
+// compressReleaseEnv opts BuildRelease into running CompressBinaries
+// afterward, e.g. UPX_RELEASE=1 mage buildRelease.
+const compressReleaseEnv = "UPX_RELEASE"
+
+// CompressBinaries runs upx --best over every cross-compiled binary under
+// bin/, shrinking release downloads at the cost of a slower first-run
+// decompression. Skips binaries that are missing or already compressed.
+func CompressBinaries() error {
+	for _, p := range crossCompileTargets {
+		dir := filepath.Join(outputDir, p.os+"-"+p.arch)
+		name := binaryName
+		if p.os == "windows" {
+			name += ".exe"
+		}
+		bin := filepath.Join(dir, name)
+		if _, err := os.Stat(bin); os.IsNotExist(err) {
+			continue
+		}
+		// upx -t succeeds only on a file upx has already packed.
+		if sh.Run("upx", "-q", "-t", bin) == nil {
+			continue
+		}
+		if err := sh.RunV("upx", "--best", bin); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func compressReleaseIfRequested() error {
+	if os.Getenv(compressReleaseEnv) == "" {
+		return nil
+	}
+	return CompressBinaries()
+}
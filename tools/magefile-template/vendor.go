@@ -0,0 +1,49 @@
+//go:build mage
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/magefile/mage/sh"
+)
+
+// vendorDir is the directory `go mod vendor` populates and Build builds
+// against when present, for repos that must build without reaching the
+// module proxy.
+const vendorDir = "vendor"
+
+// Vendor runs `go mod vendor`, refreshing the vendor/ directory from
+// go.mod/go.sum. Alias: mage deps:vendor.
+func (Deps) Vendor() error {
+	return runv("go", "mod", "vendor")
+}
+
+// hasVendorDir reports whether vendor/modules.txt exists, the marker Build
+// uses to decide whether to pass -mod=vendor.
+func hasVendorDir() bool {
+	_, err := os.Stat(vendorDir + "/modules.txt")
+	return err == nil
+}
+
+// VerifyVendor fails if the vendor directory doesn't match go.mod/go.sum —
+// `go mod vendor -diff` shows what would change without touching the tree,
+// so CI can catch a vendor/ that was hand-edited or simply never
+// regenerated after a dependency bump.
+func VerifyVendor() error {
+	if !hasVendorDir() {
+		fmt.Println("VerifyVendor: no vendor/ directory, nothing to verify")
+		return nil
+	}
+
+	diff, err := sh.Output("go", "mod", "vendor", "-diff")
+	if err != nil && diff == "" {
+		return fmt.Errorf("go mod vendor -diff: %w", err)
+	}
+	if strings.TrimSpace(diff) != "" {
+		return fmt.Errorf("VerifyVendor: vendor/ is out of sync with go.mod/go.sum, run `mage deps:vendor`:\n%s", diff)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+//go:build mage
+
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/magefile/mage/sh"
+)
+
+// BumpPatch tags a new patch release (v1.2.3 -> v1.2.4) off the latest tag.
+func BumpPatch() error { return bumpVersion("patch") }
+
+// BumpMinor tags a new minor release (v1.2.3 -> v1.3.0) off the latest tag.
+func BumpMinor() error { return bumpVersion("minor") }
+
+// BumpMajor tags a new major release (v1.2.3 -> v2.0.0) off the latest tag.
+func BumpMajor() error { return bumpVersion("major") }
+
+func bumpVersion(part string) error {
+	current, err := sh.Output("git", "describe", "--tags", "--abbrev=0")
+	if err != nil {
+		current = "v0.0.0"
+	}
+
+	next, err := nextVersion(current, part)
+	if err != nil {
+		return err
+	}
+
+	if err := sh.RunV("git", "tag", "-a", next, "-m", next); err != nil {
+		return err
+	}
+	fmt.Printf("tagged %s (was %s)\n", next, current)
+	return nil
+}
+
+// nextVersion computes the next semver tag for part ("major", "minor", or
+// "patch") given the current "vMAJOR.MINOR.PATCH" tag.
+func nextVersion(current, part string) (string, error) {
+	trimmed := strings.TrimPrefix(current, "v")
+	segments := strings.SplitN(trimmed, ".", 3)
+	if len(segments) != 3 {
+		return "", fmt.Errorf("cannot parse semver from tag %q", current)
+	}
+
+	major, err := strconv.Atoi(segments[0])
+	if err != nil {
+		return "", fmt.Errorf("cannot parse semver from tag %q: %w", current, err)
+	}
+	minor, err := strconv.Atoi(segments[1])
+	if err != nil {
+		return "", fmt.Errorf("cannot parse semver from tag %q: %w", current, err)
+	}
+	patch, err := strconv.Atoi(strings.SplitN(segments[2], "-", 2)[0])
+	if err != nil {
+		return "", fmt.Errorf("cannot parse semver from tag %q: %w", current, err)
+	}
+
+	switch part {
+	case "major":
+		major, minor, patch = major+1, 0, 0
+	case "minor":
+		minor, patch = minor+1, 0
+	case "patch":
+		patch++
+	default:
+		return "", fmt.Errorf("unknown version part %q", part)
+	}
+
+	return fmt.Sprintf("v%d.%d.%d", major, minor, patch), nil
+}
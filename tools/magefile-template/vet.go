@@ -0,0 +1,43 @@
+//go:build mage
+
+package main
+
+import (
+	"os"
+	"strings"
+
+	"github.com/magefile/mage/sh"
+)
+
+// Vet runs `go vet ./...`. go vet has no SARIF writer (like grype in
+// security.go), so SARIF_OUT is a no-op here; it's a first-class target in
+// its own right for teams that haven't adopted golangci-lint.
+func Vet() error {
+	return sh.RunV("go", "vet", "./...")
+}
+
+// Staticcheck runs staticcheck, scoped to cfg.StaticcheckChecks when set
+// (e.g. ["SA1000", "-ST1000"] to enable SA1000 and disable ST1000 on top of
+// staticcheck's default set), with SARIF output when SARIF_OUT is set.
+func Staticcheck() error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	args := []string{"run", "honnef.co/go/tools/cmd/staticcheck@latest"}
+	if len(cfg.StaticcheckChecks) > 0 {
+		args = append(args, "-checks", strings.Join(append([]string{"inherit"}, cfg.StaticcheckChecks...), ","))
+	}
+	if !sarifEnabled() {
+		args = append(args, "./...")
+		return sh.RunV("go", args...)
+	}
+
+	args = append(args, "-f", "sarif", "./...")
+	out, err := sh.Output("go", args...)
+	if writeErr := os.WriteFile(sarifPath("staticcheck"), []byte(out), 0o644); writeErr != nil {
+		return writeErr
+	}
+	return err
+}
@@ -0,0 +1,69 @@
+//go:build mage
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// waiversPath lists vulnerabilities the team has explicitly accepted, each
+// with an expiry so a waiver can't silently outlive the risk assessment
+// that justified it.
+const waiversPath = "security-waivers.json"
+
+// waiver is one accepted finding.
+type waiver struct {
+	ID        string    `json:"id"` // e.g. CVE-2024-12345 or GO-2024-1234
+	Reason    string    `json:"reason"`
+	Owner     string    `json:"owner"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// loadWaivers reads security-waivers.json, returning an empty map if it
+// doesn't exist.
+func loadWaivers() (map[string]waiver, error) {
+	data, err := os.ReadFile(waiversPath)
+	if os.IsNotExist(err) {
+		return map[string]waiver{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var list []waiver
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", waiversPath, err)
+	}
+
+	byID := make(map[string]waiver, len(list))
+	for _, w := range list {
+		byID[w.ID] = w
+	}
+	return byID, nil
+}
+
+// applyWaivers removes any finding ID covered by an unexpired waiver from
+// findings, returning the remainder plus the IDs of any waiver that has
+// expired (which should be surfaced so someone re-reviews it).
+func applyWaivers(findings []string) (remaining []string, expired []string, err error) {
+	waivers, err := loadWaivers()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	for _, id := range findings {
+		w, waived := waivers[id]
+		switch {
+		case !waived:
+			remaining = append(remaining, id)
+		case now.After(w.ExpiresAt):
+			expired = append(expired, id)
+			remaining = append(remaining, id)
+		}
+	}
+	return remaining, expired, nil
+}
@@ -0,0 +1,59 @@
+//go:build mage
+
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/magefile/mage/sh"
+)
+
+// BuildWasm cross-compiles to GOOS=js GOARCH=wasm, for running in a browser,
+// and copies wasm_exec.js from the Go toolchain's misc/wasm directory
+// alongside the binary so the output directory is directly servable.
+func BuildWasm() error {
+	dir := filepath.Join(outputDir, "wasm")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	cmd := exec.Command("go", "build", "-o", filepath.Join(dir, binaryName+".wasm"), mainPath)
+	cmd.Env = append(os.Environ(), "GOOS=js", "GOARCH=wasm")
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return err
+	}
+
+	return copyWasmExec(dir)
+}
+
+// BuildWasi cross-compiles to GOOS=wasip1 GOARCH=wasm for running under a
+// WASI-compliant host (wasmtime, wazero), which needs no JS glue.
+func BuildWasi() error {
+	dir := filepath.Join(outputDir, "wasi")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	cmd := exec.Command("go", "build", "-o", filepath.Join(dir, binaryName+".wasm"), mainPath)
+	cmd.Env = append(os.Environ(), "GOOS=wasip1", "GOARCH=wasm")
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+func copyWasmExec(destDir string) error {
+	goroot, err := sh.Output("go", "env", "GOROOT")
+	if err != nil {
+		return err
+	}
+	src := filepath.Join(goroot, "misc", "wasm", "wasm_exec.js")
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(destDir, "wasm_exec.js"), data, 0o644)
+}
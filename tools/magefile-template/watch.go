@@ -0,0 +1,64 @@
+//go:build mage
+
+package main
+
+import (
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// watchDebounce coalesces bursts of filesystem events (e.g. an editor saving
+// several files at once) into a single rebuild.
+const watchDebounce = 200 * time.Millisecond
+
+// Watch rebuilds and restarts the service whenever a .go file under the
+// module changes. It polls mtimes rather than depending on a platform
+// fsnotify binding, so it works unmodified on every OS mage supports.
+func Watch() error {
+	var proc *exec.Cmd
+	restart := func() {
+		if proc != nil && proc.Process != nil {
+			_ = proc.Process.Kill()
+			_ = proc.Wait()
+		}
+		if err := Build(); err != nil {
+			log.Printf("build failed: %v", err)
+			return
+		}
+		proc = exec.Command(filepath.Join(outputDir, binaryName))
+		proc.Stdout = os.Stdout
+		proc.Stderr = os.Stderr
+		if err := proc.Start(); err != nil {
+			log.Printf("restart failed: %v", err)
+		}
+	}
+
+	restart()
+
+	lastChange := time.Now()
+	knownMtimes := map[string]time.Time{}
+	for {
+		time.Sleep(watchDebounce)
+
+		changed := false
+		_ = filepath.Walk(".", func(path string, info os.FileInfo, err error) error {
+			if err != nil || info.IsDir() || !strings.HasSuffix(path, ".go") {
+				return nil
+			}
+			if prev, ok := knownMtimes[path]; !ok || info.ModTime().After(prev) {
+				knownMtimes[path] = info.ModTime()
+				changed = true
+			}
+			return nil
+		})
+
+		if changed && time.Since(lastChange) > watchDebounce {
+			lastChange = time.Now()
+			restart()
+		}
+	}
+}
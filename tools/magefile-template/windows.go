@@ -0,0 +1,15 @@
+//go:build mage
+
+package main
+
+import "runtime"
+
+// exeSuffix is ".exe" on Windows and empty everywhere else. Targets that
+// build or reference a binary path by name should append this rather than
+// special-casing runtime.GOOS themselves.
+func exeSuffix() string {
+	if runtime.GOOS == "windows" {
+		return ".exe"
+	}
+	return ""
+}
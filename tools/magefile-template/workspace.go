@@ -0,0 +1,179 @@
+//go:build mage
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"golang.org/x/mod/modfile"
+)
+
+// goWorkFile is the workspace manifest InstallDeps/Tidy/Verify look for
+// before falling back to treating the current directory as the only module.
+const goWorkFile = "go.work"
+
+// InstallDeps downloads module dependencies for the current module, or for
+// every workspace module listed in go.work, so cloning a go.work-based
+// monorepo only needs one `mage installdeps`.
+func InstallDeps() error {
+	return forEachWorkspaceModule(func(dir string) error {
+		return runInDir(dir, "go", "mod", "download")
+	})
+}
+
+// Tidy runs `go mod tidy` in the current module, or in every workspace
+// module listed in go.work.
+func Tidy() error {
+	return forEachWorkspaceModule(func(dir string) error {
+		return runInDir(dir, "go", "mod", "tidy")
+	})
+}
+
+// Verify runs `go mod verify` in every workspace module, then
+// WorkspaceDepsCheck to catch shared dependencies pinned to different
+// versions across modules — something per-module `go mod verify` can't see,
+// since each module's own go.sum is internally consistent on its own.
+func Verify() error {
+	if err := forEachWorkspaceModule(func(dir string) error {
+		return runInDir(dir, "go", "mod", "verify")
+	}); err != nil {
+		return err
+	}
+	return WorkspaceDepsCheck()
+}
+
+// WorkspaceDepsCheck fails if two workspace modules require different
+// versions of the same dependency, the cross-module drift `go work sync`
+// is meant to prevent but that a forgotten sync lets back in.
+func WorkspaceDepsCheck() error {
+	dirs, err := workspaceModuleDirs()
+	if err != nil {
+		return err
+	}
+	if len(dirs) < 2 {
+		return nil
+	}
+
+	versionsByModule := map[string]map[string]string{} // dep -> version -> last module that required it
+	for _, dir := range dirs {
+		reqs, err := moduleRequirements(dir)
+		if err != nil {
+			return fmt.Errorf("%s: %w", dir, err)
+		}
+		for dep, version := range reqs {
+			if versionsByModule[dep] == nil {
+				versionsByModule[dep] = map[string]string{}
+			}
+			versionsByModule[dep][version] = dir
+		}
+	}
+
+	var mismatches []string
+	for dep, byVersion := range versionsByModule {
+		if len(byVersion) < 2 {
+			continue
+		}
+		versions := make([]string, 0, len(byVersion))
+		for v := range byVersion {
+			versions = append(versions, v)
+		}
+		sort.Strings(versions)
+		mismatches = append(mismatches, fmt.Sprintf("  %s: %v", dep, versions))
+	}
+	if len(mismatches) == 0 {
+		return nil
+	}
+
+	sort.Strings(mismatches)
+	return fmt.Errorf("WorkspaceDepsCheck: mismatched dependency versions across workspace modules, run `go work sync`:\n%s",
+		joinLines(mismatches))
+}
+
+func joinLines(lines []string) string {
+	out := ""
+	for i, l := range lines {
+		if i > 0 {
+			out += "\n"
+		}
+		out += l
+	}
+	return out
+}
+
+// forEachWorkspaceModule runs fn once per workspaceModuleDirs entry, in
+// sorted order, stopping at the first failure.
+func forEachWorkspaceModule(fn func(dir string) error) error {
+	dirs, err := workspaceModuleDirs()
+	if err != nil {
+		return err
+	}
+	for _, dir := range dirs {
+		Step("module %s", dir)
+		if err := fn(dir); err != nil {
+			return fmt.Errorf("%s: %w", dir, err)
+		}
+	}
+	return nil
+}
+
+// workspaceModuleDirs returns the `use` directories declared in go.work, or
+// [.] when no go.work exists, so every caller works the same whether or not
+// the module has opted into a workspace.
+func workspaceModuleDirs() ([]string, error) {
+	data, err := os.ReadFile(goWorkFile)
+	if os.IsNotExist(err) {
+		return []string{"."}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	wf, err := modfile.ParseWork(goWorkFile, data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", goWorkFile, err)
+	}
+
+	dirs := make([]string, 0, len(wf.Use))
+	for _, u := range wf.Use {
+		dirs = append(dirs, filepath.Clean(u.Path))
+	}
+	sort.Strings(dirs)
+	return dirs, nil
+}
+
+// moduleRequirements maps each direct requirement in dir/go.mod to its
+// version string.
+func moduleRequirements(dir string) (map[string]string, error) {
+	data, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		return nil, err
+	}
+	mf, err := modfile.Parse(filepath.Join(dir, "go.mod"), data, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	reqs := make(map[string]string, len(mf.Require))
+	for _, r := range mf.Require {
+		reqs[r.Mod.Path] = r.Mod.Version
+	}
+	return reqs, nil
+}
+
+// runInDir runs cmd via runv with the working directory temporarily changed
+// to dir, restoring it afterward even on failure.
+func runInDir(dir, cmd string, args ...string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	if err := os.Chdir(dir); err != nil {
+		return err
+	}
+	defer os.Chdir(cwd)
+
+	return runv(cmd, args...)
+}
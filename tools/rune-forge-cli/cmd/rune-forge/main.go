@@ -0,0 +1,89 @@
+// Command rune-forge scaffolds new projects from the stack templates under
+// tools/. Run `rune-forge init <stack> <dest>` to copy a template into a new
+// directory, e.g. `rune-forge init go-service ./payments`. Pass
+// `-values=<file>` to render the template's .tmpl files against a YAML
+// values file instead of just the inferred ServiceName. For the
+// go-service/go-react stacks, `-module` and `-binary-name` seed the
+// template's ModulePath/BinaryName values and are prompted for
+// interactively when omitted; `-ci` names a CI provider to point you at
+// after scaffolding (see scaffold.CIGenerateTarget).
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jpoley/rune-forge/tools/rune-forge-cli/internal/scaffold"
+)
+
+func main() {
+	if len(os.Args) < 2 || os.Args[1] != "init" {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	fs := flag.NewFlagSet("init", flag.ExitOnError)
+	valuesPath := fs.String("values", "", "YAML file of template values")
+	modulePath := fs.String("module", "", "Go module path for the new project (go-service/go-react only; prompted if empty)")
+	binaryName := fs.String("binary-name", "", "name of the built binary (go-service/go-react only; prompted if empty)")
+	ciProvider := fs.String("ci", "", "CI provider to point you at after scaffolding: github, gitlab, azure, or jenkins (prompted if empty)")
+	if err := fs.Parse(os.Args[2:]); err != nil {
+		os.Exit(2)
+	}
+	if fs.NArg() < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+	stack, dest := fs.Arg(0), fs.Arg(1)
+	// flag stops parsing at the first positional argument, so parse the
+	// flags that follow <stack> <dest> as well.
+	if err := fs.Parse(fs.Args()[2:]); err != nil {
+		os.Exit(2)
+	}
+	if fs.NArg() != 0 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	in := bufio.NewReader(os.Stdin)
+	opts := scaffold.Options{ModulePath: *modulePath, BinaryName: *binaryName, CIProvider: *ciProvider}
+	if scaffold.WantsGoModule(stack) {
+		opts.ModulePath = promptIfEmpty(in, opts.ModulePath, fmt.Sprintf("Go module path (e.g. github.com/you/%s): ", dest))
+		opts.BinaryName = promptIfEmpty(in, opts.BinaryName, "binary name [service]: ")
+	}
+	opts.CIProvider = promptIfEmpty(in, opts.CIProvider, "CI provider (github/gitlab/azure/jenkins, blank to skip): ")
+
+	if opts.CIProvider != "" {
+		if _, err := scaffold.CIGenerateTarget(opts.CIProvider); err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			os.Exit(2)
+		}
+	}
+
+	if err := scaffold.Init(stack, dest, *valuesPath, opts); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("scaffolded %s into %s\n", stack, dest)
+	if opts.CIProvider != "" {
+		target, _ := scaffold.CIGenerateTarget(opts.CIProvider)
+		fmt.Printf("run `mage %s` inside %s to generate its %s CI config\n", target, dest, opts.CIProvider)
+	}
+}
+
+const usage = "usage: rune-forge init <stack> <dest> [-values=<file>] [-module=<path>] [-binary-name=<name>] [-ci=<provider>]"
+
+// promptIfEmpty returns current if it's non-empty, otherwise prints prompt
+// to stderr and reads one line from in, trimmed of its trailing newline.
+func promptIfEmpty(in *bufio.Reader, current, prompt string) string {
+	if current != "" {
+		return current
+	}
+	fmt.Fprint(os.Stderr, prompt)
+	line, _ := in.ReadString('\n')
+	return strings.TrimSpace(line)
+}
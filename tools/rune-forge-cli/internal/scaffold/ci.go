@@ -0,0 +1,33 @@
+package scaffold
+
+import "fmt"
+
+// ciGenerateTargets maps a CI provider name to the mage target (in
+// ../magefile-template's ci_github.go / ci_gitlab.go / ci_azure_jenkins.go)
+// that writes that provider's pipeline config. Scaffolding doesn't
+// duplicate that rendering logic here; it just tells the contributor which
+// target to run once the project exists (see cmd/rune-forge/main.go).
+var ciGenerateTargets = map[string]string{
+	"github":  "generateGithubActions",
+	"gitlab":  "generateGitlabCI",
+	"azure":   "generateAzurePipelines",
+	"jenkins": "generateJenkinsfile",
+}
+
+// CIGenerateTarget returns the mage target that generates provider's CI
+// config, or an error if provider isn't one ci.go knows about.
+func CIGenerateTarget(provider string) (string, error) {
+	target, ok := ciGenerateTargets[provider]
+	if !ok {
+		return "", fmt.Errorf("unknown CI provider %q, known providers: %v", provider, knownCIProviders())
+	}
+	return target, nil
+}
+
+func knownCIProviders() []string {
+	providers := make([]string, 0, len(ciGenerateTargets))
+	for name := range ciGenerateTargets {
+		providers = append(providers, name)
+	}
+	return providers
+}
@@ -0,0 +1,90 @@
+package scaffold
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// scaffoldFrontend writes a minimal Vite + React starter into dest/frontend
+// so the go-react stack's hasFrontend() check (../magefile-template/embed.go)
+// is true from the first commit, instead of leaving a contributor to
+// discover that convention on their own. It's deliberately small: just
+// enough to build and embed, not a design system.
+func scaffoldFrontend(dest string) error {
+	frontendDir := filepath.Join(dest, "frontend")
+	if err := os.MkdirAll(filepath.Join(frontendDir, "src"), 0o755); err != nil {
+		return err
+	}
+
+	serviceName := filepath.Base(dest)
+	files := map[string]string{
+		"package.json":   frontendPackageJSON(serviceName),
+		"index.html":     frontendIndexHTML,
+		"vite.config.ts": frontendViteConfig,
+		"src/main.tsx":   frontendMainTSX,
+	}
+	for rel, contents := range files {
+		path := filepath.Join(frontendDir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func frontendPackageJSON(serviceName string) string {
+	return fmt.Sprintf(`{
+  "name": %q,
+  "private": true,
+  "type": "module",
+  "scripts": {
+    "dev": "vite",
+    "build": "vite build"
+  },
+  "dependencies": {
+    "react": "^18.3.1",
+    "react-dom": "^18.3.1"
+  },
+  "devDependencies": {
+    "@vitejs/plugin-react": "^4.3.1",
+    "typescript": "^5.5.4",
+    "vite": "^5.3.4"
+  }
+}
+`, serviceName+"-frontend")
+}
+
+const frontendIndexHTML = `<!doctype html>
+<html lang="en">
+  <head>
+    <meta charset="UTF-8" />
+    <title>Service</title>
+  </head>
+  <body>
+    <div id="root"></div>
+    <script type="module" src="/src/main.tsx"></script>
+  </body>
+</html>
+`
+
+const frontendViteConfig = `import { defineConfig } from 'vite'
+import react from '@vitejs/plugin-react'
+
+export default defineConfig({
+  plugins: [react()],
+})
+`
+
+const frontendMainTSX = `import React from 'react'
+import ReactDOM from 'react-dom/client'
+
+ReactDOM.createRoot(document.getElementById('root')!).render(
+  <React.StrictMode>
+    <p>It works. Replace this with your app.</p>
+  </React.StrictMode>,
+)
+`
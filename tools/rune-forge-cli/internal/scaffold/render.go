@@ -0,0 +1,66 @@
+package scaffold
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// valuesFile is the optional values file passed via `rune-forge init
+// <stack> <dest> -values=<path>`. Any file in the template ending in
+// .tmpl is rendered against these values with text/template; every other
+// file is copied verbatim.
+//
+// Values are also seeded with ServiceName (derived from dest) and, for the
+// go-service/go-react stacks, ModulePath and BinaryName (from Options; see
+// Init), so a template's magefile/workflow/Dockerfile can reference
+// {{.ServiceName}}, {{.ModulePath}}, and {{.BinaryName}} without a values
+// file at all.
+type Values map[string]any
+
+// loadValues reads a YAML values file, or returns an empty Values if path
+// is empty.
+func loadValues(path string) (Values, error) {
+	values := Values{}
+	if path == "" {
+		return values, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading values file %s: %w", path, err)
+	}
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("parsing values file %s: %w", path, err)
+	}
+	return values, nil
+}
+
+// renderTemplate renders a single .tmpl file's contents against values.
+func renderTemplate(name string, contents []byte, values Values) ([]byte, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(string(contents))
+	if err != nil {
+		return nil, fmt.Errorf("parsing template %s: %w", name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, values); err != nil {
+		return nil, fmt.Errorf("rendering template %s: %w", name, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// isTemplateFile reports whether path should be rendered rather than
+// copied verbatim.
+func isTemplateFile(path string) bool {
+	return strings.HasSuffix(path, ".tmpl")
+}
+
+// renderedPath strips the .tmpl suffix a template file was copied under.
+func renderedPath(path string) string {
+	return strings.TrimSuffix(path, ".tmpl")
+}
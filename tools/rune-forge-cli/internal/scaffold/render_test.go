@@ -0,0 +1,116 @@
+package scaffold
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIsTemplateFile(t *testing.T) {
+	cases := []struct {
+		path string
+		want bool
+	}{
+		{"magefile.go.tmpl", true},
+		{"README.md.tmpl", true},
+		{"magefile.go", false},
+		{"Dockerfile", false},
+	}
+
+	for _, c := range cases {
+		if got := isTemplateFile(c.path); got != c.want {
+			t.Errorf("isTemplateFile(%q) = %v, want %v", c.path, got, c.want)
+		}
+	}
+}
+
+func TestRenderedPath(t *testing.T) {
+	cases := []struct {
+		path string
+		want string
+	}{
+		{"magefile.go.tmpl", "magefile.go"},
+		{"ci.yml.tmpl", "ci.yml"},
+		{"Dockerfile", "Dockerfile"},
+	}
+
+	for _, c := range cases {
+		if got := renderedPath(c.path); got != c.want {
+			t.Errorf("renderedPath(%q) = %q, want %q", c.path, got, c.want)
+		}
+	}
+}
+
+func TestRenderTemplate(t *testing.T) {
+	cases := []struct {
+		name    string
+		tmpl    string
+		values  Values
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "substitutes known values",
+			tmpl:   "module {{.ModulePath}}\nbinary {{.BinaryName}}\n",
+			values: Values{"ModulePath": "github.com/acme/payments", "BinaryName": "payments"},
+			want:   "module github.com/acme/payments\nbinary payments\n",
+		},
+		{
+			name:    "missing key errors instead of rendering <no value>",
+			tmpl:    "{{.ModulePath}}",
+			values:  Values{},
+			wantErr: true,
+		},
+		{
+			name:    "invalid template syntax errors",
+			tmpl:    "{{.Unclosed",
+			values:  Values{},
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := renderTemplate("test", []byte(c.tmpl), c.values)
+			if (err != nil) != c.wantErr {
+				t.Fatalf("renderTemplate() error = %v, wantErr %v", err, c.wantErr)
+			}
+			if err == nil && string(got) != c.want {
+				t.Errorf("renderTemplate() = %q, want %q", got, c.want)
+			}
+		})
+	}
+}
+
+func TestLoadValues(t *testing.T) {
+	t.Run("empty path returns empty values", func(t *testing.T) {
+		values, err := loadValues("")
+		if err != nil {
+			t.Fatalf("loadValues(\"\") error = %v", err)
+		}
+		if len(values) != 0 {
+			t.Errorf("loadValues(\"\") = %v, want empty", values)
+		}
+	})
+
+	t.Run("parses a YAML values file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "values.yaml")
+		if err := os.WriteFile(path, []byte("ServiceName: payments\nReplicas: 3\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		values, err := loadValues(path)
+		if err != nil {
+			t.Fatalf("loadValues(%q) error = %v", path, err)
+		}
+		if values["ServiceName"] != "payments" {
+			t.Errorf("loadValues(%q)[\"ServiceName\"] = %v, want %q", path, values["ServiceName"], "payments")
+		}
+	})
+
+	t.Run("missing file errors", func(t *testing.T) {
+		if _, err := loadValues(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+			t.Error("loadValues() with missing file: got nil error, want non-nil")
+		}
+	})
+}
@@ -0,0 +1,153 @@
+// Package scaffold copies a stack template into a new project directory.
+package scaffold
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// stackTemplates maps a stack name to its template directory, relative to
+// the repo root. "go-react" reuses the go-service template rather than
+// forking it: ../magefile-template already builds and embeds a frontend/
+// directory when one exists (see embed.go there), so the only thing
+// go-react adds on top of go-service is a starter frontend/ tree (see
+// scaffoldFrontend) that makes hasFrontend() true from the first commit.
+var stackTemplates = map[string]string{
+	"go-service": "tools/magefile-template",
+	"go-react":   "tools/magefile-template",
+	"node":       "tools/magefile-template-node",
+	"rust":       "tools/magefile-template-rust",
+}
+
+// Options carries the per-invocation answers (from flags or interactive
+// prompts; see cmd/rune-forge) that Init seeds into the template's values
+// on top of whatever valuesPath provides.
+type Options struct {
+	// ModulePath is the new project's Go module path, e.g.
+	// "github.com/acme/payments". Ignored for the node and rust stacks.
+	ModulePath string
+	// BinaryName overrides the template's default "service" binary name.
+	// Ignored for the node and rust stacks.
+	BinaryName string
+	// CIProvider is one of the providers ci.go knows how to point a
+	// contributor at (see CIGenerateTarget); empty skips CI setup entirely.
+	CIProvider string
+}
+
+// WantsGoModule reports whether stack produces a Go module, i.e. whether
+// ModulePath/BinaryName are meaningful for it.
+func WantsGoModule(stack string) bool {
+	return stack == "go-service" || stack == "go-react"
+}
+
+// Init copies the named stack's template into dest and records the template
+// directory it came from in dest/.rune-forge-template, so SyncTemplate (in the Go
+// template's own magefile) can later pull upstream improvements.
+//
+// Any file in the template ending in .tmpl is rendered with text/template
+// against valuesPath (a YAML file) before being written, so emitted files
+// like the magefile, CI workflows, and Dockerfiles can be parameterized
+// instead of hard-coding a service name or module path. valuesPath may be
+// empty, in which case only the values seeded from opts and dest (see
+// Values) are available.
+func Init(stack, dest, valuesPath string, opts Options) error {
+	src, ok := stackTemplates[stack]
+	if !ok {
+		return fmt.Errorf("unknown stack %q, known stacks: %v", stack, knownStacks())
+	}
+
+	values, err := loadValues(valuesPath)
+	if err != nil {
+		return err
+	}
+	if _, ok := values["ServiceName"]; !ok {
+		values["ServiceName"] = filepath.Base(dest)
+	}
+	if opts.ModulePath != "" {
+		values["ModulePath"] = opts.ModulePath
+	}
+	if opts.BinaryName != "" {
+		values["BinaryName"] = opts.BinaryName
+	}
+	if opts.CIProvider != "" {
+		values["CIProvider"] = opts.CIProvider
+	}
+
+	if err := copyDir(src, dest, values); err != nil {
+		return err
+	}
+	if stack == "go-react" {
+		if err := scaffoldFrontend(dest); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(filepath.Join(dest, ".rune-forge-template"), []byte(src+"\n"), 0o644)
+}
+
+func knownStacks() []string {
+	stacks := make([]string, 0, len(stackTemplates))
+	for name := range stackTemplates {
+		stacks = append(stacks, name)
+	}
+	return stacks
+}
+
+func copyDir(src, dest string, values Values) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dest, rel)
+
+		if info.IsDir() {
+			return os.MkdirAll(target, 0o755)
+		}
+		if isTemplateFile(path) {
+			return copyRenderedFile(path, renderedPath(target), info.Mode(), values)
+		}
+		return copyFile(path, target, info.Mode())
+	})
+}
+
+// copyRenderedFile renders src as a text/template against values and writes
+// the result to dest.
+func copyRenderedFile(src, dest string, mode os.FileMode, values Values) error {
+	contents, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	rendered, err := renderTemplate(src, contents, values)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(dest, rendered, mode)
+}
+
+func copyFile(src, dest string, mode os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		return err
+	}
+	out, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, in)
+	return err
+}
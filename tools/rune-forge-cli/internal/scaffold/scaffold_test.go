@@ -0,0 +1,132 @@
+package scaffold
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWantsGoModule(t *testing.T) {
+	cases := []struct {
+		stack string
+		want  bool
+	}{
+		{"go-service", true},
+		{"go-react", true},
+		{"node", false},
+		{"rust", false},
+		{"unknown", false},
+	}
+
+	for _, c := range cases {
+		if got := WantsGoModule(c.stack); got != c.want {
+			t.Errorf("WantsGoModule(%q) = %v, want %v", c.stack, got, c.want)
+		}
+	}
+}
+
+func TestInitUnknownStack(t *testing.T) {
+	if err := Init("cobol", filepath.Join(t.TempDir(), "dest"), "", Options{}); err == nil {
+		t.Error("Init() with unknown stack: got nil error, want non-nil")
+	}
+}
+
+// TestInitCopiesAndRenders exercises Init end-to-end against a fake
+// template directory (rather than the real tools/magefile-template, which
+// would make this test depend on sibling-package layout), covering the
+// verbatim copy, .tmpl rendering, ModulePath/BinaryName seeding, and
+// .rune-forge-template bookkeeping in one pass.
+func TestInitCopiesAndRenders(t *testing.T) {
+	srcRoot := t.TempDir()
+	writeFile(t, filepath.Join(srcRoot, "Dockerfile"), "FROM scratch\n")
+	writeFile(t, filepath.Join(srcRoot, "magefile.go.tmpl"), "binary = {{.BinaryName}}\nmodule = {{.ModulePath}}\n")
+
+	orig := stackTemplates["go-service"]
+	stackTemplates["go-service"] = srcRoot
+	t.Cleanup(func() { stackTemplates["go-service"] = orig })
+
+	dest := filepath.Join(t.TempDir(), "payments")
+	opts := Options{ModulePath: "github.com/acme/payments", BinaryName: "payments"}
+	if err := Init("go-service", dest, "", opts); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	if got := readFile(t, filepath.Join(dest, "Dockerfile")); got != "FROM scratch\n" {
+		t.Errorf("Dockerfile = %q, want verbatim copy", got)
+	}
+
+	want := "binary = payments\nmodule = github.com/acme/payments\n"
+	if got := readFile(t, filepath.Join(dest, "magefile.go")); got != want {
+		t.Errorf("magefile.go = %q, want %q", got, want)
+	}
+	if _, err := os.Stat(filepath.Join(dest, "magefile.go.tmpl")); !os.IsNotExist(err) {
+		t.Error("magefile.go.tmpl should not exist after rendering, only magefile.go")
+	}
+
+	if got := readFile(t, filepath.Join(dest, ".rune-forge-template")); got != srcRoot+"\n" {
+		t.Errorf(".rune-forge-template = %q, want %q", got, srcRoot+"\n")
+	}
+}
+
+func TestInitGoReactScaffoldsFrontend(t *testing.T) {
+	srcRoot := t.TempDir()
+	writeFile(t, filepath.Join(srcRoot, "magefile.go"), "package main\n")
+
+	orig := stackTemplates["go-react"]
+	stackTemplates["go-react"] = srcRoot
+	t.Cleanup(func() { stackTemplates["go-react"] = orig })
+
+	dest := filepath.Join(t.TempDir(), "payments")
+	if err := Init("go-react", dest, "", Options{}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	for _, rel := range []string{"frontend/package.json", "frontend/index.html", "frontend/vite.config.ts", "frontend/src/main.tsx"} {
+		if _, err := os.Stat(filepath.Join(dest, filepath.FromSlash(rel))); err != nil {
+			t.Errorf("expected %s to exist after go-react Init: %v", rel, err)
+		}
+	}
+}
+
+func TestCIGenerateTarget(t *testing.T) {
+	cases := []struct {
+		provider string
+		want     string
+		wantErr  bool
+	}{
+		{"github", "generateGithubActions", false},
+		{"gitlab", "generateGitlabCI", false},
+		{"azure", "generateAzurePipelines", false},
+		{"jenkins", "generateJenkinsfile", false},
+		{"bamboo", "", true},
+	}
+
+	for _, c := range cases {
+		got, err := CIGenerateTarget(c.provider)
+		if (err != nil) != c.wantErr {
+			t.Fatalf("CIGenerateTarget(%q) error = %v, wantErr %v", c.provider, err, c.wantErr)
+		}
+		if got != c.want {
+			t.Errorf("CIGenerateTarget(%q) = %q, want %q", c.provider, got, c.want)
+		}
+	}
+}
+
+func writeFile(t *testing.T, path, contents string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}